@@ -0,0 +1,128 @@
+package age
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"filippo.io/age"
+	"github.com/pkg/errors"
+)
+
+// LoadIdentities loads X25519 identities (AGE-SECRET-KEY-1...) from the given identity file
+// The file is the same as the one generated by age-keygen. Empty lines and lines starting with "#" are ignored.
+func LoadIdentities(filename string) ([]age.Identity, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to read identity file. filename=%s", filename)
+	}
+	defer f.Close()
+
+	identities, err := age.ParseIdentities(f)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to parse identity file. filename=%s", filename)
+	}
+
+	return identities, nil
+}
+
+// Client represents the encryption provider using age X25519 recipients
+type Client struct {
+	recipients   []string
+	identityFile string
+
+	once       sync.Once
+	identities []age.Identity
+	loadErr    error
+}
+
+// NewClient creates new Client object
+// Recipients are used for encryption, and identities in identityFile are used for decryption.
+func NewClient(recipients []string, identityFile string) *Client {
+	return &Client{
+		recipients:   recipients,
+		identityFile: identityFile,
+	}
+}
+
+// DecryptBase64 decrypts the given base64-encoded cipher text
+func (c *Client) DecryptBase64(namespace, key, cipherText string) (string, error) {
+	c.once.Do(func() {
+		c.identities, c.loadErr = LoadIdentities(c.identityFile)
+	})
+	if c.loadErr != nil {
+		return "", errors.Wrap(c.loadErr, "Failed to load identities.")
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(cipherText)
+	if err != nil {
+		return "", errors.Wrapf(err, "Failed to decode as base64 string. text=%q", cipherText)
+	}
+
+	r, err := age.Decrypt(bytes.NewReader(decoded), c.identities...)
+	if err != nil {
+		return "", errors.Wrapf(err, "Failed to decrypt the given cipherText. namespace=%s, key=%s, cipherText=%q", namespace, key, cipherText)
+	}
+
+	plaintext, err := ioutil.ReadAll(r)
+	if err != nil {
+		return "", errors.Wrapf(err, "Failed to decrypt the given cipherText. namespace=%s, key=%s, cipherText=%q", namespace, key, cipherText)
+	}
+
+	header := encryptionContext(namespace, key)
+
+	if !bytes.HasPrefix(plaintext, header) {
+		return "", errors.Errorf("Cipher text is bound to another namespace or key. namespace=%s, key=%s", namespace, key)
+	}
+
+	return string(plaintext[len(header):]), nil
+}
+
+// EncryptBase64 encrypts the given text and returns as base64-encoded cipher text
+// keyAlias is ignored because recipients are given to NewClient.
+func (c *Client) EncryptBase64(keyAlias, namespace, key, text string) (string, error) {
+	if len(c.recipients) == 0 {
+		return "", errors.New("No recipient is given.")
+	}
+
+	recipients := []age.Recipient{}
+
+	for _, recipient := range c.recipients {
+		r, err := age.ParseX25519Recipient(recipient)
+		if err != nil {
+			return "", errors.Wrapf(err, "Failed to parse recipient. recipient=%s", recipient)
+		}
+
+		recipients = append(recipients, r)
+	}
+
+	var buf bytes.Buffer
+
+	w, err := age.Encrypt(&buf, recipients...)
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to initialize encryption.")
+	}
+
+	if _, err := w.Write(append(encryptionContext(namespace, key), text...)); err != nil {
+		return "", errors.Wrap(err, "Failed to encrypt the given text.")
+	}
+
+	if err := w.Close(); err != nil {
+		return "", errors.Wrap(err, "Failed to encrypt the given text.")
+	}
+
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// encryptionContext returns header lines equivalent to KMS encryption context, terminated by an empty line
+// age has no additional authenticated data, so the header is encrypted together with the value and checked on decryption.
+// Cipher texts are plain age files, and the header is shown before the value if they are decrypted by age command.
+func encryptionContext(namespace, key string) []byte {
+	if namespace == "" {
+		return []byte("key=" + key + "\n\n")
+	}
+
+	return []byte("key=" + key + "\nnamespace=" + namespace + "\n\n")
+}
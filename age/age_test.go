@@ -0,0 +1,171 @@
+package age
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"filippo.io/age"
+)
+
+// generated by age-keygen
+const (
+	testIdentity  = "AGE-SECRET-KEY-17773Y73F4XJL575MDJ8NDEZDLZSLCWA4NM7V8K3TQHEDSMP0R3JQDMSP58"
+	testRecipient = "age1mpfjdtzj5fcsh9nqsxdh057wqz2605mr06tdy6ne8ean2mdtmpsq2lx0lg"
+)
+
+func writeIdentityFile(t *testing.T, dir string, identities ...*age.X25519Identity) string {
+	body := "# created for test\n"
+
+	for _, identity := range identities {
+		body += identity.String() + "\n"
+	}
+
+	filename := filepath.Join(dir, "identity.txt")
+	if err := ioutil.WriteFile(filename, []byte(body), 0600); err != nil {
+		t.Fatalf("Failed to create identity file. filename: %s", filename)
+	}
+
+	return filename
+}
+
+func TestLoadIdentities_invalid(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test-age")
+	if err != nil {
+		t.Fatalf("Failed to create tempdir. dir: %s", dir)
+	}
+	defer os.RemoveAll(dir)
+
+	testcases := []string{
+		"",
+		"# no identity\n",
+		testRecipient + "\n",
+		"AGE-SECRET-KEY-17773Y73F4XJL575MDJ8NDEZDLZSLCWA4NM7V8K3TQHEDSMP0R3JQDMSP59\n",
+	}
+
+	for _, tc := range testcases {
+		filename := filepath.Join(dir, "identity.txt")
+		if err := ioutil.WriteFile(filename, []byte(tc), 0600); err != nil {
+			t.Fatalf("Failed to create identity file. filename: %s", filename)
+		}
+
+		if _, err := LoadIdentities(filename); err == nil {
+			t.Errorf("Error should be raised. identity file: %q", tc)
+		}
+	}
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test-age")
+	if err != nil {
+		t.Fatalf("Failed to create tempdir. dir: %s", dir)
+	}
+	defer os.RemoveAll(dir)
+
+	alice, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
+	}
+
+	bob, err := age.ParseX25519Identity(testIdentity)
+	if err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
+	}
+
+	if bob.Recipient().String() != testRecipient {
+		t.Fatalf("Recipient does not match. expected: %s, actual: %s", testRecipient, bob.Recipient().String())
+	}
+
+	encrypter := NewClient([]string{alice.Recipient().String(), testRecipient}, "")
+
+	cipherText, err := encrypter.EncryptBase64("", "production", "FOO", "bar")
+	if err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
+	}
+
+	for _, identity := range []*age.X25519Identity{alice, bob} {
+		decrypter := NewClient([]string{}, writeIdentityFile(t, dir, identity))
+
+		actual, err := decrypter.DecryptBase64("production", "FOO", cipherText)
+		if err != nil {
+			t.Errorf("Error should not be raised. error: %s", err)
+		}
+
+		if actual != "bar" {
+			t.Errorf("Plain text does not match. expected: %q, actual: %q", "bar", actual)
+		}
+
+		if _, err := decrypter.DecryptBase64("production", "BAZ", cipherText); err == nil {
+			t.Errorf("Error should be raised with another key.")
+		}
+
+		if _, err := decrypter.DecryptBase64("staging", "FOO", cipherText); err == nil {
+			t.Errorf("Error should be raised with another namespace.")
+		}
+	}
+
+	carol, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
+	}
+
+	decrypter := NewClient([]string{}, writeIdentityFile(t, dir, carol))
+
+	if _, err := decrypter.DecryptBase64("production", "FOO", cipherText); err == nil {
+		t.Errorf("Error should be raised with unknown identity.")
+	}
+}
+
+func TestEncryptBase64_ageFile(t *testing.T) {
+	identity, err := age.ParseX25519Identity(testIdentity)
+	if err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
+	}
+
+	client := NewClient([]string{testRecipient}, "")
+
+	cipherText, err := client.EncryptBase64("", "production", "FOO", "bar")
+	if err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(cipherText)
+	if err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
+	}
+
+	// Cipher text can be decrypted by age itself
+	r, err := age.Decrypt(bytes.NewReader(decoded), identity)
+	if err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
+	}
+
+	actual, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
+	}
+
+	expected := "key=FOO\nnamespace=production\n\nbar"
+	if string(actual) != expected {
+		t.Errorf("Plain text does not match. expected: %q, actual: %q", expected, string(actual))
+	}
+}
+
+func TestEncryptBase64_invalidRecipient(t *testing.T) {
+	testcases := [][]string{
+		[]string{},
+		[]string{testIdentity},
+		[]string{"age1mpfjdtzj5fcsh9nqsxdh057wqz2605mr06tdy6ne8ean2mdtmpsq2lx0lh"},
+	}
+
+	for _, tc := range testcases {
+		client := NewClient(tc, "")
+
+		if _, err := client.EncryptBase64("", "production", "FOO", "bar"); err == nil {
+			t.Errorf("Error should be raised. recipients: %q", tc)
+		}
+	}
+}
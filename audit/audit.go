@@ -0,0 +1,120 @@
+package audit
+
+import (
+	"encoding/json"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// OutcomeSucceeded represents that command finished without error
+	OutcomeSucceeded = "succeeded"
+	// OutcomeFailed represents that command failed
+	OutcomeFailed = "failed"
+	// OutcomePending represents that command finished without error, but left some changes unapplied
+	OutcomePending = "pending"
+)
+
+const (
+	syslogScheme    = "syslog"
+	syslogTCPScheme = "syslog+tcp"
+)
+
+// Entry represents one line of audit log
+// Only keys are recorded, values are never included.
+type Entry struct {
+	Time      time.Time `json:"time"`
+	Command   string    `json:"command"`
+	Table     string    `json:"table,omitempty"`
+	Namespace string    `json:"namespace,omitempty"`
+	Keys      []string  `json:"keys,omitempty"`
+	Identity  string    `json:"identity,omitempty"`
+	User      string    `json:"user,omitempty"`
+	Outcome   string    `json:"outcome"`
+}
+
+// Logger represents the sink which audit log is appended to
+type Logger struct {
+	w io.WriteCloser
+}
+
+// NewLogger creates new Logger object writing to the given writer
+func NewLogger(w io.WriteCloser) *Logger {
+	return &Logger{
+		w: w,
+	}
+}
+
+// Open opens the sink of audit log
+// destination is a file path, "syslog" for the local syslog daemon,
+// syslog://HOST:PORT (UDP), syslog+tcp://HOST:PORT or syslog:///PATH/TO/SOCKET.
+func Open(destination string) (*Logger, error) {
+	if destination == syslogScheme || strings.HasPrefix(destination, syslogScheme+":") || strings.HasPrefix(destination, syslogTCPScheme+":") {
+		network, raddr, err := parseSyslogAddress(destination)
+		if err != nil {
+			return nil, err
+		}
+
+		w, err := dialSyslog(network, raddr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to connect to syslog. destination=%s", destination)
+		}
+
+		return NewLogger(w), nil
+	}
+
+	fp, err := os.OpenFile(destination, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to open audit log file. filename=%s", destination)
+	}
+
+	return NewLogger(fp), nil
+}
+
+// Write appends the given entry as one JSON line
+func (l *Logger) Write(e *Entry) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return errors.Wrap(err, "Failed to convert audit log entry as JSON.")
+	}
+
+	if _, err := l.w.Write(append(body, '\n')); err != nil {
+		return errors.Wrap(err, "Failed to write audit log entry.")
+	}
+
+	return nil
+}
+
+// Close closes the sink of audit log
+func (l *Logger) Close() error {
+	return l.w.Close()
+}
+
+// parseSyslogAddress returns network and address of syslog daemon
+// Empty network and address mean the local syslog daemon.
+func parseSyslogAddress(destination string) (string, string, error) {
+	if destination == syslogScheme {
+		return "", "", nil
+	}
+
+	u, err := url.Parse(destination)
+	if err != nil {
+		return "", "", errors.Wrapf(err, "Failed to parse syslog address. destination=%s", destination)
+	}
+
+	switch {
+	case u.Host != "" && u.Scheme == syslogTCPScheme:
+		return "tcp", u.Host, nil
+	case u.Host != "" && u.Scheme == syslogScheme:
+		return "udp", u.Host, nil
+	case u.Path != "" && u.Scheme == syslogScheme:
+		return "unixgram", u.Path, nil
+	default:
+		return "", "", errors.Errorf("Invalid syslog address. destination=%s", destination)
+	}
+}
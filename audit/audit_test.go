@@ -0,0 +1,128 @@
+package audit
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestOpen_file(t *testing.T) {
+	dir, err := ioutil.TempDir("", "valec-audit")
+	if err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "audit.log")
+
+	entries := []*Entry{
+		&Entry{
+			Time:      time.Date(2017, 1, 2, 3, 4, 5, 0, time.UTC),
+			Command:   "valec get",
+			Table:     "valec",
+			Namespace: "hoge",
+			Keys:      []string{"FOO"},
+			Identity:  "arn:aws:iam::123456789012:user/valec",
+			User:      "valec",
+			Outcome:   OutcomeSucceeded,
+		},
+		&Entry{
+			Time:    time.Date(2017, 1, 2, 3, 4, 6, 0, time.UTC),
+			Command: "valec version",
+			Outcome: OutcomeFailed,
+		},
+	}
+
+	for _, e := range entries {
+		l, err := Open(filename)
+		if err != nil {
+			t.Fatalf("Error should not be raised. error: %s", err)
+		}
+
+		if err := l.Write(e); err != nil {
+			t.Fatalf("Error should not be raised. error: %s", err)
+		}
+
+		if err := l.Close(); err != nil {
+			t.Fatalf("Error should not be raised. error: %s", err)
+		}
+	}
+
+	body, err := ioutil.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
+	}
+
+	expected := `{"time":"2017-01-02T03:04:05Z","command":"valec get","table":"valec","namespace":"hoge","keys":["FOO"],"identity":"arn:aws:iam::123456789012:user/valec","user":"valec","outcome":"succeeded"}
+{"time":"2017-01-02T03:04:06Z","command":"valec version","outcome":"failed"}
+`
+	if string(body) != expected {
+		t.Errorf("audit log does not match. expected: %q, actual: %q", expected, string(body))
+	}
+
+	fi, err := os.Stat(filename)
+	if err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
+	}
+
+	if fi.Mode().Perm() != 0600 {
+		t.Errorf("permission does not match. expected: %o, actual: %o", 0600, fi.Mode().Perm())
+	}
+}
+
+func TestOpen_error(t *testing.T) {
+	testcases := []string{
+		filepath.Join("testdata", "notexist", "audit.log"),
+		"syslog://",
+		"syslog+tcp:///dev/log",
+	}
+
+	for _, destination := range testcases {
+		if _, err := Open(destination); err == nil {
+			t.Errorf("Error should be raised. destination: %s", destination)
+		}
+	}
+}
+
+func TestParseSyslogAddress(t *testing.T) {
+	testcases := []struct {
+		destination string
+		network     string
+		raddr       string
+	}{
+		{
+			destination: "syslog",
+			network:     "",
+			raddr:       "",
+		},
+		{
+			destination: "syslog://localhost:514",
+			network:     "udp",
+			raddr:       "localhost:514",
+		},
+		{
+			destination: "syslog+tcp://localhost:514",
+			network:     "tcp",
+			raddr:       "localhost:514",
+		},
+		{
+			destination: "syslog:///dev/log",
+			network:     "unixgram",
+			raddr:       "/dev/log",
+		},
+	}
+
+	for _, tc := range testcases {
+		network, raddr, err := parseSyslogAddress(tc.destination)
+		if err != nil {
+			t.Errorf("Error should not be raised. destination: %s, error: %s", tc.destination, err)
+			continue
+		}
+
+		if network != tc.network || raddr != tc.raddr {
+			t.Errorf("address does not match. destination: %s, expected: %s %s, actual: %s %s", tc.destination, tc.network, tc.raddr, network, raddr)
+		}
+	}
+}
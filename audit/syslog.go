@@ -0,0 +1,17 @@
+//go:build !windows && !nacl && !plan9
+// +build !windows,!nacl,!plan9
+
+package audit
+
+import (
+	"io"
+	"log/syslog"
+)
+
+const syslogTag = "valec"
+
+// dialSyslog connects to syslog daemon
+// Each entry is sent as one message with AUTH facility.
+func dialSyslog(network, raddr string) (io.WriteCloser, error) {
+	return syslog.Dial(network, raddr, syslog.LOG_AUTH|syslog.LOG_INFO, syslogTag)
+}
@@ -0,0 +1,66 @@
+//go:build !windows && !nacl && !plan9
+// +build !windows,!nacl,!plan9
+
+package audit
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOpen_syslog(t *testing.T) {
+	dir, err := ioutil.TempDir("", "valec-audit")
+	if err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	socket := filepath.Join(dir, "log.sock")
+
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
+	}
+	defer conn.Close()
+
+	l, err := Open("syslog://" + socket)
+	if err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
+	}
+	defer l.Close()
+
+	if err := l.Write(&Entry{
+		Time:      time.Date(2017, 1, 2, 3, 4, 5, 0, time.UTC),
+		Command:   "valec get",
+		Namespace: "hoge",
+		Outcome:   OutcomeSucceeded,
+	}); err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
+	}
+
+	buf := make([]byte, 1024)
+
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	n, err := conn.Read(buf)
+	if err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
+	}
+
+	message := string(buf[:n])
+
+	expected := "valec["
+	if !strings.Contains(message, expected) {
+		t.Errorf("message should contain tag. expected: %q, actual: %q", expected, message)
+	}
+
+	expected = `{"time":"2017-01-02T03:04:05Z","command":"valec get","namespace":"hoge","outcome":"succeeded"}`
+	if !strings.Contains(message, expected) {
+		t.Errorf("message should contain entry. expected: %q, actual: %q", expected, message)
+	}
+}
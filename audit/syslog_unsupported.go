@@ -0,0 +1,15 @@
+//go:build windows || nacl || plan9
+// +build windows nacl plan9
+
+package audit
+
+import (
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// dialSyslog is not supported on this platform
+func dialSyslog(network, raddr string) (io.WriteCloser, error) {
+	return nil, errors.New("syslog is not supported on this platform.")
+}
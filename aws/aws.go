@@ -1,12 +1,16 @@
 package aws
 
 import (
+	"sync"
+
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	dynamodbapi "github.com/aws/aws-sdk-go/service/dynamodb"
 	kmsapi "github.com/aws/aws-sdk-go/service/kms"
+	stsapi "github.com/aws/aws-sdk-go/service/sts"
 	"github.com/dtan4/valec/aws/dynamodb"
 	"github.com/dtan4/valec/aws/kms"
+	"github.com/dtan4/valec/aws/sts"
 	"github.com/pkg/errors"
 )
 
@@ -15,6 +19,15 @@ var (
 	DynamoDB *dynamodb.Client
 	// KMS represents KMS API client
 	KMS *kms.Client
+	// STS represents STS API client
+	STS *sts.Client
+
+	regionalKMS = struct {
+		sync.Mutex
+		m map[string]*kms.Client
+	}{
+		m: map[string]*kms.Client{},
+	}
 )
 
 // Initialize initializes AWS API clients
@@ -38,6 +51,32 @@ func Initialize(region string) error {
 
 	DynamoDB = dynamodb.NewClient(dynamodbapi.New(sess))
 	KMS = kms.NewClient(kmsapi.New(sess))
+	STS = sts.NewClient(stsapi.New(sess))
 
 	return nil
 }
+
+// KMSForRegion returns KMS API client for the given region
+// Empty region means the region given to Initialize.
+func KMSForRegion(region string) (*kms.Client, error) {
+	if region == "" {
+		return KMS, nil
+	}
+
+	regionalKMS.Lock()
+	defer regionalKMS.Unlock()
+
+	if c, ok := regionalKMS.m[region]; ok {
+		return c, nil
+	}
+
+	sess, err := session.NewSession(&aws.Config{Region: aws.String(region)})
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to create new AWS session. region=%s", region)
+	}
+
+	c := kms.NewClient(kmsapi.New(sess))
+	regionalKMS.m[region] = c
+
+	return c, nil
+}
@@ -1,30 +1,323 @@
 package dynamodb
 
 import (
+	"fmt"
+	"math/rand"
+	"regexp"
 	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
 	"github.com/dtan4/valec/secret"
+	"github.com/dtan4/valec/util"
 	"github.com/pkg/errors"
 )
 
 const (
 	// http://docs.aws.amazon.com/amazondynamodb/latest/APIReference/API_BatchWriteItem.html
 	batchWriteItemMax = 25
+
+	// https://docs.aws.amazon.com/amazondynamodb/latest/APIReference/API_TransactWriteItems.html
+	transactWriteItemsMax = 100
+
+	// namespaceRegistry is the reserved namespace which holds one item per namespace
+	// Namespaces cannot be named as this because util.ValidateNamespace rejects it.
+	namespaceRegistry = ".namespaces"
+
+	// registryMarker is the key of registry item which tells that all namespaces in the table are registered
+	// Namespaces cannot be named as this because util.ValidateNamespace rejects it.
+	registryMarker = ".migrated"
+
+	// historyPrefix is the prefix of reserved namespaces which hold previous versions of secrets
+	// Previous versions of secrets in namespace "foo" are stored in ".history/foo".
+	historyPrefix = ".history/"
+
+	// trashNamespace is the reserved namespace which holds deleted secrets of all namespaces
+	// Deleted secret "FOO" in namespace "foo" is stored with key "foo#FOO#<deleted time>".
+	trashNamespace = ".trash"
+
+	// TrashRetention is how long deleted secrets are kept in trash
+	// Items are expired by DynamoDB TTL on expires_at attribute, which must be enabled on the table.
+	TrashRetention = 30 * 24 * time.Hour
+
+	// DefaultMaxRetries represents the default number of retries for unprocessed items
+	DefaultMaxRetries = 5
+	// MaxRetriesLimit represents the upper limit of the number of retries for unprocessed items
+	MaxRetriesLimit = 20
+
+	// retryBackoffBase is the maximum wait before the first retry, doubled on each retry
+	retryBackoffBase = 100 * time.Millisecond
+	// maxRetryBackoff is the upper limit of the maximum wait before each retry
+	maxRetryBackoff = 20 * time.Second
 )
 
+// absent is the expected version of secrets which must not exist, even if they were written before version was introduced
+const absent int64 = -1
+
+// sleep is replaced in tests to avoid waiting
+var sleep = time.Sleep
+
+// now is replaced in tests to fix timestamps
+var now = time.Now
+
+// cancellationReasonsRegexp matches the list of cancellation reason codes at the end of TransactionCanceledException message
+// e.g. "Transaction cancelled, please refer cancellation reasons for specific reasons [None, ConditionalCheckFailed]"
+var cancellationReasonsRegexp = regexp.MustCompile(`\[([A-Za-z]+(?:, [A-Za-z]+)*)\]$`)
+
+// jitter is the random source of retry backoff, which is separated from the global one
+// rand.Rand is not safe for concurrent use, so it is guarded by the mutex.
+var jitter = struct {
+	sync.Mutex
+	r *rand.Rand
+}{
+	r: rand.New(rand.NewSource(time.Now().UnixNano())),
+}
+
 // Client represents the wrapper of DynamoDB API client
 type Client struct {
-	api dynamodbiface.DynamoDBAPI
+	api        dynamodbiface.DynamoDBAPI
+	maxRetries int
 }
 
 // NewClient creates new Client object
 func NewClient(api dynamodbiface.DynamoDBAPI) *Client {
 	return &Client{
-		api: api,
+		api:        api,
+		maxRetries: DefaultMaxRetries,
+	}
+}
+
+// SetMaxRetries sets the number of retries for items which BatchWriteItem left unprocessed
+func (c *Client) SetMaxRetries(maxRetries int) error {
+	if maxRetries < 0 || maxRetries > MaxRetriesLimit {
+		return errors.Errorf("Max retries must be between 0 and %d. maxRetries=%d", MaxRetriesLimit, maxRetries)
+	}
+
+	c.maxRetries = maxRetries
+
+	return nil
+}
+
+// Apply writes and deletes secrets in the namespace atomically
+// Changes are written by TransactWriteItems together with history and trash items of overwritten and deleted secrets,
+// so that readers see either all of the changes or none of them. Each change is conditioned on the stored version,
+// and secrets changed by someone else are reported as secret.ConflictError without applying any change.
+// Changes which do not fit in one transaction are split into several transactions, writing secrets before any deletion
+// so that no key is absent in the middle. If any of them fails, transactions committed so far are reverted,
+// writing back originals of overwritten secrets. Keys which could not be reverted are listed in the returned error.
+func (c *Client) Apply(table, namespace string, puts, deletes, originals []*secret.Secret) error {
+	if err := util.ValidateNamespace(namespace); err != nil {
+		return err
+	}
+
+	originalMap := map[string]*secret.Secret{}
+	for _, o := range originals {
+		originalMap[o.Key] = o
+	}
+
+	changes := []*transactChange{}
+
+	for _, s := range puts {
+		change := &transactChange{
+			key: s.Key,
+			items: []*dynamodb.TransactWriteItem{
+				&dynamodb.TransactWriteItem{
+					Put: secretPut(table, namespace, s, s.Version, s.Version+1),
+				},
+			},
+		}
+
+		if o, ok := originalMap[s.Key]; ok {
+			change.items = append(change.items, &dynamodb.TransactWriteItem{
+				Put: &dynamodb.Put{
+					TableName: aws.String(table),
+					Item:      historyItem(namespace, storedItem(namespace, o)),
+				},
+			})
+			change.undos = append(change.undos, &dynamodb.TransactWriteItem{
+				Put: secretPut(table, namespace, o, s.Version+1, s.Version+2),
+			})
+		} else {
+			change.undos = append(change.undos, &dynamodb.TransactWriteItem{
+				Delete: secretDelete(table, namespace, s.Key, s.Version+1),
+			})
+		}
+
+		changes = append(changes, change)
+	}
+
+	for _, s := range deletes {
+		old := storedItem(namespace, s)
+		t := trashItem(namespace, old)
+
+		changes = append(changes, &transactChange{
+			key: s.Key,
+			items: []*dynamodb.TransactWriteItem{
+				&dynamodb.TransactWriteItem{
+					Delete: secretDelete(table, namespace, s.Key, s.Version),
+				},
+				&dynamodb.TransactWriteItem{
+					Put: &dynamodb.Put{
+						TableName: aws.String(table),
+						Item:      historyItem(namespace, old),
+					},
+				},
+				&dynamodb.TransactWriteItem{
+					Put: &dynamodb.Put{
+						TableName: aws.String(table),
+						Item:      t,
+					},
+				},
+			},
+			undos: []*dynamodb.TransactWriteItem{
+				&dynamodb.TransactWriteItem{
+					Put: secretPut(table, namespace, s, absent, s.Version+1),
+				},
+				&dynamodb.TransactWriteItem{
+					Delete: trashDelete(table, *t["key"].S),
+				},
+			},
+		})
+	}
+
+	transactions := splitTransactions(changes)
+
+	for i, changes := range transactions {
+		if err := c.transactWrite(changes, false); err != nil {
+			if keys := conflictedKeys(changes, err); len(keys) > 0 {
+				err = &secret.ConflictError{
+					Namespace: namespace,
+					Keys:      keys,
+				}
+			}
+
+			return c.revert(transactions[:i], err)
+		}
+	}
+
+	if len(puts) > 0 {
+		if err := c.registerNamespace(table, namespace); err != nil {
+			return errors.Wrapf(err, "Failed to register namespace. namespace=%s", namespace)
+		}
+	} else if len(deletes) > 0 {
+		if err := c.deregisterIfEmpty(table, namespace); err != nil {
+			return errors.Wrapf(err, "Failed to deregister namespace. namespace=%s", namespace)
+		}
+	}
+
+	return nil
+}
+
+// transactChange represents items written in one transaction to change one secret, and items which revert them
+type transactChange struct {
+	key   string
+	items []*dynamodb.TransactWriteItem
+	undos []*dynamodb.TransactWriteItem
+}
+
+// splitTransactions packs the given changes into transactions in order
+// Items of one change are never split, so that each secret is changed together with its history and trash.
+func splitTransactions(changes []*transactChange) [][]*transactChange {
+	transactions := [][]*transactChange{}
+	size := 0
+
+	for _, change := range changes {
+		if len(transactions) == 0 || size+len(change.items) > transactWriteItemsMax {
+			transactions = append(transactions, []*transactChange{})
+			size = 0
+		}
+
+		transactions[len(transactions)-1] = append(transactions[len(transactions)-1], change)
+		size += len(change.items)
+	}
+
+	return transactions
+}
+
+// transactWrite writes items of the given changes, or items which revert them, in one transaction
+func (c *Client) transactWrite(changes []*transactChange, undo bool) error {
+	items := []*dynamodb.TransactWriteItem{}
+
+	for _, change := range changes {
+		if undo {
+			items = append(items, change.undos...)
+		} else {
+			items = append(items, change.items...)
+		}
+	}
+
+	_, err := c.api.TransactWriteItems(&dynamodb.TransactWriteItemsInput{
+		TransactItems: items,
+	})
+
+	return err
+}
+
+// revert reverts the given committed transactions in reverse order, and returns the given error with the result
+// History items written by the committed transactions remain.
+func (c *Client) revert(transactions [][]*transactChange, err error) error {
+	reverted := 0
+	failed := []string{}
+
+	for i := len(transactions) - 1; i >= 0; i-- {
+		if c.transactWrite(transactions[i], true) != nil {
+			for _, change := range transactions[i] {
+				failed = append(failed, change.key)
+			}
+
+			continue
+		}
+
+		reverted += len(transactions[i])
+	}
+
+	if len(failed) > 0 {
+		return errors.Wrapf(err, "Failed to revert changes. Please check these secrets. reverted=%d, keys=%s", reverted, strings.Join(failed, ","))
+	}
+
+	if len(transactions) > 0 {
+		return errors.Wrapf(err, "Changes were reverted. reverted=%d", reverted)
+	}
+
+	return errors.Wrap(err, "No change was applied.")
+}
+
+// conflictedKeys returns keys of the given changes whose condition failed in the canceled transaction
+// CancellationReasons are not exposed by SDK, so their codes are read from the error message in the order of items.
+func conflictedKeys(changes []*transactChange, err error) []string {
+	aerr, ok := err.(awserr.Error)
+	if !ok || aerr.Code() != "TransactionCanceledException" {
+		return []string{}
+	}
+
+	m := cancellationReasonsRegexp.FindStringSubmatch(aerr.Message())
+	if m == nil {
+		return []string{}
+	}
+
+	reasons := strings.Split(m[1], ", ")
+	keys := []string{}
+	i := 0
+
+	for _, change := range changes {
+		for range change.items {
+			if i < len(reasons) && reasons[i] == "ConditionalCheckFailed" {
+				if len(keys) == 0 || keys[len(keys)-1] != change.key {
+					keys = append(keys, change.key)
+				}
+			}
+
+			i++
+		}
 	}
+
+	return keys
 }
 
 // CreateTable creates new table for Valec
@@ -60,26 +353,73 @@ func (c *Client) CreateTable(table string) error {
 		return errors.Wrapf(err, "Failed to create DynamoDB table. table=%s", table)
 	}
 
+	if err := c.api.WaitUntilTableExists(&dynamodb.DescribeTableInput{
+		TableName: aws.String(table),
+	}); err != nil {
+		return errors.Wrapf(err, "Failed to wait for DynamoDB table to be created. table=%s", table)
+	}
+
+	// New table has no namespace which is not registered
+	if err := c.registerNamespace(table, registryMarker); err != nil {
+		return errors.Wrapf(err, "Failed to mark namespace registry as migrated. table=%s", table)
+	}
+
 	return nil
 }
 
 // Delete deletes records from DynamoDB table
+// Each record is deleted only if its version is the same as Version of the given secret.
+// The record is kept in history and moved to trash before it is deleted, so that it is never lost.
+// Secrets changed by someone else are reported as secret.ConflictError.
 func (c *Client) Delete(table, namespace string, secrets []*secret.Secret) error {
-	if len(secrets) == 0 {
-		return nil
-	}
+	conflicted := []string{}
 
-	for i := 0; i < (len(secrets)-1)/batchWriteItemMax+1; i++ {
-		var max int
+	for _, secret := range secrets {
+		old, err := c.getItem(table, namespace, secret.Key)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to retrieve item. key=%s", secret.Key)
+		}
 
-		if len(secrets[i*batchWriteItemMax:]) >= batchWriteItemMax {
-			max = (i + 1) * batchWriteItemMax
-		} else {
-			max = i*batchWriteItemMax + len(secrets[i*batchWriteItemMax:])
+		if len(old) > 0 && secretFromItem(old).Version != secret.Version {
+			conflicted = append(conflicted, secret.Key)
+			continue
 		}
 
-		if err := c.doBatchDelete(table, namespace, secrets[i*batchWriteItemMax:max]); err != nil {
-			return errors.Wrap(err, "Failed to delete items.")
+		if err := c.archive(table, namespace, old); err != nil {
+			return errors.Wrapf(err, "Failed to archive previous version. key=%s", secret.Key)
+		}
+
+		id, err := c.trash(table, namespace, old)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to move item to trash. key=%s", secret.Key)
+		}
+
+		if _, err := c.deleteSecret(table, namespace, secret.Key, secret.Version); err != nil {
+			if !isConditionalCheckFailed(err) {
+				return errors.Wrapf(err, "Failed to delete item. key=%s", secret.Key)
+			}
+
+			// Item changed after it was retrieved is left as it is, so its copy must not be restored
+			if id != "" {
+				if err := c.deleteTrash(table, id); err != nil {
+					return errors.Wrapf(err, "Failed to remove item from trash. key=%s", secret.Key)
+				}
+			}
+
+			conflicted = append(conflicted, secret.Key)
+		}
+	}
+
+	if len(secrets) > 0 {
+		if err := c.deregisterIfEmpty(table, namespace); err != nil {
+			return errors.Wrapf(err, "Failed to deregister namespace. namespace=%s", namespace)
+		}
+	}
+
+	if len(conflicted) > 0 {
+		return &secret.ConflictError{
+			Namespace: namespace,
+			Keys:      conflicted,
 		}
 	}
 
@@ -87,6 +427,7 @@ func (c *Client) Delete(table, namespace string, secrets []*secret.Secret) error
 }
 
 // DeleteNamespace deletes all items in the given namespace
+// Deleted items are moved to trash before they are deleted.
 func (c *Client) DeleteNamespace(table, namespace string) error {
 	keyConditions := map[string]*dynamodb.Condition{
 		"namespace": &dynamodb.Condition{
@@ -103,36 +444,54 @@ func (c *Client) DeleteNamespace(table, namespace string) error {
 		KeyConditions: keyConditions,
 	}
 
-	resp, err := c.api.Query(params)
+	items, err := c.queryAll(params)
 	if err != nil {
 		return errors.Wrapf(err, "Failed to list up secrets. namespace=%s", namespace)
 	}
 
 	secrets := []*secret.Secret{}
+	trashRequests := []*dynamodb.WriteRequest{}
 
-	for _, item := range resp.Items {
+	for _, item := range items {
 		secret := &secret.Secret{
 			Key:   *item["key"].S,
 			Value: *item["value"].S,
 		}
 
 		secrets = append(secrets, secret)
+		trashRequests = append(trashRequests, &dynamodb.WriteRequest{
+			PutRequest: &dynamodb.PutRequest{
+				Item: trashItem(namespace, item),
+			},
+		})
 	}
 
-	for i := 0; i < (len(secrets)-1)/batchWriteItemMax+1; i++ {
-		var max int
+	for i := 0; i < len(trashRequests); i += batchWriteItemMax {
+		max := i + batchWriteItemMax
+		if max > len(trashRequests) {
+			max = len(trashRequests)
+		}
 
-		if len(secrets[i*batchWriteItemMax:]) >= batchWriteItemMax {
-			max = (i + 1) * batchWriteItemMax
-		} else {
-			max = i*batchWriteItemMax + len(secrets[i*batchWriteItemMax:])
+		if err := c.batchWrite(table, trashRequests[i:max]); err != nil {
+			return errors.Wrap(err, "Failed to move items to trash.")
+		}
+	}
+
+	for i := 0; i < len(secrets); i += batchWriteItemMax {
+		max := i + batchWriteItemMax
+		if max > len(secrets) {
+			max = len(secrets)
 		}
 
-		if err := c.doBatchDelete(table, namespace, secrets[i*batchWriteItemMax:max]); err != nil {
+		if err := c.doBatchDelete(table, namespace, secrets[i:max]); err != nil {
 			return errors.Wrap(err, "Failed to delete items.")
 		}
 	}
 
+	if err := c.deregisterNamespace(table, namespace); err != nil {
+		return errors.Wrapf(err, "Failed to deregister namespace. namespace=%s", namespace)
+	}
+
 	return nil
 }
 
@@ -154,14 +513,8 @@ func (c *Client) doBatchDelete(table, namespace string, secrets []*secret.Secret
 		})
 	}
 
-	requestItems := make(map[string][]*dynamodb.WriteRequest)
-	requestItems[table] = writeRequests
-
-	_, err := c.api.BatchWriteItem(&dynamodb.BatchWriteItemInput{
-		RequestItems: requestItems,
-	})
-	if err != nil {
-		return errors.Wrap(err, "Failed to insert items.")
+	if err := c.batchWrite(table, writeRequests); err != nil {
+		return errors.Wrap(err, "Failed to delete items.")
 	}
 
 	return nil
@@ -201,64 +554,96 @@ func (c *Client) Get(table, namespace, key string) (*secret.Secret, error) {
 		return nil, errors.Errorf("No secret matched. namespace=%s, key=%s", namespace, key)
 	}
 
-	return &secret.Secret{
-		Key:   *resp.Items[0]["key"].S,
-		Value: *resp.Items[0]["value"].S,
-	}, nil
+	return secretFromItem(resp.Items[0]), nil
 }
 
-// Insert creates / updates records of secrets in DynamoDB table
-func (c *Client) Insert(table, namespace string, secrets []*secret.Secret) error {
-	if len(secrets) == 0 {
-		return nil
+// History returns previous versions of the secret with the given key, oldest first
+// Deleted secrets are also kept in history.
+func (c *Client) History(table, namespace, key string) ([]*secret.Secret, error) {
+	items, err := c.queryAll(&dynamodb.QueryInput{
+		TableName: aws.String(table),
+		KeyConditions: map[string]*dynamodb.Condition{
+			"namespace": &dynamodb.Condition{
+				ComparisonOperator: aws.String(dynamodb.ComparisonOperatorEq),
+				AttributeValueList: []*dynamodb.AttributeValue{
+					&dynamodb.AttributeValue{
+						S: aws.String(historyPrefix + namespace),
+					},
+				},
+			},
+			"key": &dynamodb.Condition{
+				ComparisonOperator: aws.String(dynamodb.ComparisonOperatorBeginsWith),
+				AttributeValueList: []*dynamodb.AttributeValue{
+					&dynamodb.AttributeValue{
+						S: aws.String(key + "#"),
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return []*secret.Secret{}, errors.Wrapf(err, "Failed to retrieve history. namespace=%s, key=%s", namespace, key)
 	}
 
-	for i := 0; i < (len(secrets)-1)/batchWriteItemMax+1; i++ {
-		var max int
+	secrets := []*secret.Secret{}
 
-		if len(secrets[i*batchWriteItemMax:]) >= batchWriteItemMax {
-			max = (i + 1) * batchWriteItemMax
-		} else {
-			max = i*batchWriteItemMax + len(secrets[i*batchWriteItemMax:])
+	for _, item := range items {
+		// Keys containing "#" may share the prefix
+		if strings.Contains(strings.TrimPrefix(*item["key"].S, key+"#"), "#") {
+			continue
 		}
 
-		if err := c.doBatchInsert(table, namespace, secrets[i*batchWriteItemMax:max]); err != nil {
-			return errors.Wrap(err, "Failed to insert items.")
-		}
+		s := secretFromItem(item)
+		s.Key = key
+		// Versions restart after deletion, so history items are identified by the time they were archived
+		s.HistoryID = strings.TrimPrefix(*item["key"].S, key+"#")
+
+		secrets = append(secrets, s)
 	}
 
-	return nil
+	return secrets, nil
 }
 
-func (c *Client) doBatchInsert(table, namespace string, secrets []*secret.Secret) error {
-	writeRequests := []*dynamodb.WriteRequest{}
+// Insert creates / updates records of secrets in DynamoDB table
+// Each record is written only if its version is the same as Version of the given secret,
+// and version of the written record is incremented. Overwritten records are kept in history.
+// Secrets changed by someone else are reported as secret.ConflictError.
+func (c *Client) Insert(table, namespace string, secrets []*secret.Secret) error {
+	if len(secrets) == 0 {
+		return nil
+	}
+
+	if err := util.ValidateNamespace(namespace); err != nil {
+		return err
+	}
+
+	conflicted := []string{}
 
 	for _, secret := range secrets {
-		writeRequests = append(writeRequests, &dynamodb.WriteRequest{
-			PutRequest: &dynamodb.PutRequest{
-				Item: map[string]*dynamodb.AttributeValue{
-					"namespace": &dynamodb.AttributeValue{
-						S: aws.String(namespace),
-					},
-					"key": &dynamodb.AttributeValue{
-						S: aws.String(secret.Key),
-					},
-					"value": &dynamodb.AttributeValue{
-						S: aws.String(secret.Value),
-					},
-				},
-			},
-		})
+		old, err := c.putSecret(table, namespace, secret, secret.Version, secret.Version+1)
+		if isConditionalCheckFailed(err) {
+			conflicted = append(conflicted, secret.Key)
+			continue
+		}
+
+		if err != nil {
+			return errors.Wrapf(err, "Failed to insert item. key=%s", secret.Key)
+		}
+
+		if err := c.archive(table, namespace, old); err != nil {
+			return errors.Wrapf(err, "Failed to archive previous version. key=%s", secret.Key)
+		}
 	}
 
-	requestItems := make(map[string][]*dynamodb.WriteRequest)
-	requestItems[table] = writeRequests
+	if err := c.registerNamespace(table, namespace); err != nil {
+		return errors.Wrapf(err, "Failed to register namespace. namespace=%s", namespace)
+	}
 
-	_, err := c.api.BatchWriteItem(&dynamodb.BatchWriteItemInput{
-		RequestItems: requestItems,
-	})
-	if err != nil {
-		return errors.Wrap(err, "Failed to insert items.")
+	if len(conflicted) > 0 {
+		return &secret.ConflictError{
+			Namespace: namespace,
+			Keys:      conflicted,
+		}
 	}
 
 	return nil
@@ -281,38 +666,86 @@ func (c *Client) ListSecrets(table, namespace string) ([]*secret.Secret, error)
 		KeyConditions: keyConditions,
 	}
 
-	resp, err := c.api.Query(params)
+	items, err := c.queryAll(params)
 	if err != nil {
 		return []*secret.Secret{}, errors.Wrapf(err, "Failed to list up secrets. namespace=%s", namespace)
 	}
 
 	secrets := []*secret.Secret{}
 
-	for _, item := range resp.Items {
-		secret := &secret.Secret{
-			Key:   *item["key"].S,
-			Value: *item["value"].S,
-		}
-
-		secrets = append(secrets, secret)
+	for _, item := range items {
+		secrets = append(secrets, secretFromItem(item))
 	}
 
 	return secrets, nil
 }
 
 // ListNamespaces returns all namespaces
+// Namespaces are read from namespace registry. Until the registry is marked as migrated, whole table is scanned
+// because namespaces written by older valec may not be registered.
 func (c *Client) ListNamespaces(table string) ([]string, error) {
-	resp, err := c.api.Scan(&dynamodb.ScanInput{
+	items, err := c.queryAll(&dynamodb.QueryInput{
 		TableName: aws.String(table),
+		KeyConditions: map[string]*dynamodb.Condition{
+			"namespace": &dynamodb.Condition{
+				ComparisonOperator: aws.String(dynamodb.ComparisonOperatorEq),
+				AttributeValueList: []*dynamodb.AttributeValue{
+					&dynamodb.AttributeValue{
+						S: aws.String(namespaceRegistry),
+					},
+				},
+			},
+		},
 	})
 	if err != nil {
-		return []string{}, errors.Wrapf(err, "Failed to retrieve items from DynamoDB table. table=%s", table)
+		return []string{}, errors.Wrapf(err, "Failed to retrieve namespace registry. table=%s", table)
+	}
+
+	migrated := false
+	namespaces := []string{}
+
+	for _, item := range items {
+		if key := *item["key"].S; key == registryMarker {
+			migrated = true
+		} else {
+			namespaces = append(namespaces, key)
+		}
+	}
+
+	if !migrated {
+		return c.scanNamespaces(table)
 	}
 
+	sort.Strings(namespaces)
+
+	return namespaces, nil
+}
+
+// scanNamespaces returns all namespaces by scanning whole table
+func (c *Client) scanNamespaces(table string) ([]string, error) {
+	params := &dynamodb.ScanInput{
+		TableName: aws.String(table),
+	}
 	nsmap := map[string]bool{}
 
-	for _, item := range resp.Items {
-		nsmap[*item["namespace"].S] = true
+	for {
+		resp, err := c.api.Scan(params)
+		if err != nil {
+			return []string{}, errors.Wrapf(err, "Failed to retrieve items from DynamoDB table. table=%s", table)
+		}
+
+		for _, item := range resp.Items {
+			// Reserved namespaces like registry, history and trash are not listed
+			if namespace := *item["namespace"].S; util.ValidateNamespace(namespace) == nil {
+				nsmap[namespace] = true
+			}
+		}
+
+		if len(resp.LastEvaluatedKey) == 0 {
+			break
+		}
+
+		params.ExclusiveStartKey = resp.LastEvaluatedKey
 	}
 
 	namespaces := []string{}
@@ -326,43 +759,778 @@ func (c *Client) ListNamespaces(table string) ([]string, error) {
 	return namespaces, nil
 }
 
-// NamespaceExists check whether the given table exists or not
-func (c *Client) NamespaceExists(table, namespace string) (bool, error) {
+// ListTrash returns deleted secrets in the given namespace which are not expired yet, ordered by key and deleted time
+// Deleted secrets in all namespaces are returned if namespace is empty.
+func (c *Client) ListTrash(table, namespace string) ([]*secret.Trashed, error) {
 	keyConditions := map[string]*dynamodb.Condition{
 		"namespace": &dynamodb.Condition{
 			ComparisonOperator: aws.String(dynamodb.ComparisonOperatorEq),
 			AttributeValueList: []*dynamodb.AttributeValue{
 				&dynamodb.AttributeValue{
-					S: aws.String(namespace),
+					S: aws.String(trashNamespace),
 				},
 			},
 		},
 	}
-	params := &dynamodb.QueryInput{
+
+	if namespace != "" {
+		keyConditions["key"] = &dynamodb.Condition{
+			ComparisonOperator: aws.String(dynamodb.ComparisonOperatorBeginsWith),
+			AttributeValueList: []*dynamodb.AttributeValue{
+				&dynamodb.AttributeValue{
+					S: aws.String(namespace + "#"),
+				},
+			},
+		}
+	}
+
+	items, err := c.queryAll(&dynamodb.QueryInput{
 		TableName:     aws.String(table),
 		KeyConditions: keyConditions,
+	})
+	if err != nil {
+		return []*secret.Trashed{}, errors.Wrapf(err, "Failed to retrieve trash. namespace=%s", namespace)
 	}
 
-	resp, err := c.api.Query(params)
-	if err != nil {
-		return false, errors.Wrapf(err, "Failed to list up secrets. table=%s", table)
+	trashed := []*secret.Trashed{}
+
+	for _, item := range items {
+		t := trashedFromItem(item)
+
+		// Namespaces containing "#" may share the prefix
+		if namespace != "" && t.Namespace != namespace {
+			continue
+		}
+
+		// DynamoDB TTL may leave expired items for a while
+		if !t.ExpiresAt.After(now()) {
+			continue
+		}
+
+		trashed = append(trashed, t)
 	}
 
-	return len(resp.Items) > 0, nil
+	return trashed, nil
 }
 
-// TableExists check whether the given table exists or not
-func (c *Client) TableExists(table string) (bool, error) {
-	resp, err := c.api.ListTables(&dynamodb.ListTablesInput{})
+// MigrateNamespaceRegistry registers all existing namespaces to namespace registry, and marks the registry as migrated
+// This is needed only once for tables created before namespace registry was introduced.
+func (c *Client) MigrateNamespaceRegistry(table string) ([]string, error) {
+	namespaces, err := c.scanNamespaces(table)
 	if err != nil {
-		return false, errors.Wrap(err, "Failed to retrieve DynamoDB tables.")
+		return []string{}, err
 	}
 
-	for _, tableName := range resp.TableNames {
-		if *tableName == table {
-			return true, nil
+	for i := 0; i < len(namespaces); i += batchWriteItemMax {
+		max := i + batchWriteItemMax
+		if max > len(namespaces) {
+			max = len(namespaces)
+		}
+
+		writeRequests := []*dynamodb.WriteRequest{}
+
+		for _, namespace := range namespaces[i:max] {
+			writeRequests = append(writeRequests, &dynamodb.WriteRequest{
+				PutRequest: &dynamodb.PutRequest{
+					Item: registryKey(namespace),
+				},
+			})
+		}
+
+		if err := c.batchWrite(table, writeRequests); err != nil {
+			return []string{}, errors.Wrap(err, "Failed to register namespaces.")
 		}
 	}
 
-	return false, nil
+	// Marker is written last so that registry is not used until all namespaces are registered
+	if err := c.registerNamespace(table, registryMarker); err != nil {
+		return []string{}, errors.Wrap(err, "Failed to mark namespace registry as migrated.")
+	}
+
+	return namespaces, nil
+}
+
+// NamespaceExists check whether the given table exists or not
+func (c *Client) NamespaceExists(table, namespace string) (bool, error) {
+	resp, err := c.api.GetItem(&dynamodb.GetItemInput{
+		TableName: aws.String(table),
+		Key:       registryKey(namespace),
+	})
+	if err != nil {
+		return false, errors.Wrapf(err, "Failed to retrieve namespace registry. table=%s", table)
+	}
+
+	if len(resp.Item) > 0 {
+		return true, nil
+	}
+
+	// Namespaces which are not registered yet
+	keyConditions := map[string]*dynamodb.Condition{
+		"namespace": &dynamodb.Condition{
+			ComparisonOperator: aws.String(dynamodb.ComparisonOperatorEq),
+			AttributeValueList: []*dynamodb.AttributeValue{
+				&dynamodb.AttributeValue{
+					S: aws.String(namespace),
+				},
+			},
+		},
+	}
+	params := &dynamodb.QueryInput{
+		TableName:     aws.String(table),
+		KeyConditions: keyConditions,
+	}
+
+	queryResp, err := c.api.Query(params)
+	if err != nil {
+		return false, errors.Wrapf(err, "Failed to list up secrets. table=%s", table)
+	}
+
+	return len(queryResp.Items) > 0, nil
+}
+
+// Restore writes back the given deleted secrets to the namespace and removes them from trash
+// Each secret is restored only if no secret with the same key exists, and its version is incremented.
+// Secrets which exist already are reported as secret.ConflictError.
+func (c *Client) Restore(table, namespace string, trashed []*secret.Trashed) error {
+	if len(trashed) == 0 {
+		return nil
+	}
+
+	if err := util.ValidateNamespace(namespace); err != nil {
+		return err
+	}
+
+	conflicted := []string{}
+
+	for _, t := range trashed {
+		if _, err := c.putSecret(table, namespace, t.Secret, absent, t.Secret.Version+1); err != nil {
+			if isConditionalCheckFailed(err) {
+				conflicted = append(conflicted, t.Secret.Key)
+				continue
+			}
+
+			return errors.Wrapf(err, "Failed to restore item. key=%s", t.Secret.Key)
+		}
+
+		if err := c.deleteTrash(table, t.ID); err != nil {
+			return errors.Wrapf(err, "Failed to remove item from trash. key=%s", t.Secret.Key)
+		}
+	}
+
+	if err := c.registerNamespace(table, namespace); err != nil {
+		return errors.Wrapf(err, "Failed to register namespace. namespace=%s", namespace)
+	}
+
+	if len(conflicted) > 0 {
+		return &secret.ConflictError{
+			Namespace: namespace,
+			Keys:      conflicted,
+		}
+	}
+
+	return nil
+}
+
+// TableExists check whether the given table exists or not
+func (c *Client) TableExists(table string) (bool, error) {
+	params := &dynamodb.ListTablesInput{}
+
+	for {
+		resp, err := c.api.ListTables(params)
+		if err != nil {
+			return false, errors.Wrap(err, "Failed to retrieve DynamoDB tables.")
+		}
+
+		for _, tableName := range resp.TableNames {
+			if *tableName == table {
+				return true, nil
+			}
+		}
+
+		if resp.LastEvaluatedTableName == nil {
+			break
+		}
+
+		params.ExclusiveStartTableName = resp.LastEvaluatedTableName
+	}
+
+	return false, nil
+}
+
+// batchWrite calls BatchWriteItem API, retrying unprocessed items with jittered exponential backoff
+// If some items are still unprocessed after all retries, the error lists their keys.
+func (c *Client) batchWrite(table string, writeRequests []*dynamodb.WriteRequest) error {
+	for i := 0; ; i++ {
+		resp, err := c.api.BatchWriteItem(&dynamodb.BatchWriteItemInput{
+			RequestItems: map[string][]*dynamodb.WriteRequest{
+				table: writeRequests,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		writeRequests = resp.UnprocessedItems[table]
+		if len(writeRequests) == 0 {
+			return nil
+		}
+
+		if i >= c.maxRetries {
+			break
+		}
+
+		sleep(randomWait(retryBackoff(i)))
+	}
+
+	keys := []string{}
+
+	for _, wr := range writeRequests {
+		keys = append(keys, keyOfWriteRequest(wr))
+	}
+
+	return errors.Errorf("Some items were not processed after %d retries. keys=%s", c.maxRetries, strings.Join(keys, ","))
+}
+
+// retryBackoff returns the maximum wait before the given retry
+// Wait is doubled on each retry up to maxRetryBackoff, so that it never overflows.
+func retryBackoff(i int) time.Duration {
+	backoff := retryBackoffBase
+
+	for j := 0; j < i && backoff < maxRetryBackoff; j++ {
+		backoff *= 2
+	}
+
+	if backoff > maxRetryBackoff {
+		return maxRetryBackoff
+	}
+
+	return backoff
+}
+
+// randomWait returns random duration shorter than the given one
+func randomWait(max time.Duration) time.Duration {
+	jitter.Lock()
+	defer jitter.Unlock()
+
+	return time.Duration(jitter.r.Int63n(int64(max)))
+}
+
+// putSecret writes the given secret only if the stored version is the expected one
+// The overwritten item is returned, which is empty if the secret did not exist.
+func (c *Client) putSecret(table, namespace string, secret *secret.Secret, expected, next int64) (map[string]*dynamodb.AttributeValue, error) {
+	put := secretPut(table, namespace, secret, expected, next)
+
+	resp, err := c.api.PutItem(&dynamodb.PutItemInput{
+		TableName:                 put.TableName,
+		Item:                      put.Item,
+		ConditionExpression:       put.ConditionExpression,
+		ExpressionAttributeNames:  put.ExpressionAttributeNames,
+		ExpressionAttributeValues: put.ExpressionAttributeValues,
+		ReturnValues:              aws.String(dynamodb.ReturnValueAllOld),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Attributes, nil
+}
+
+// secretPut returns the write of the given secret which passes only if the stored version is the expected one
+func secretPut(table, namespace string, secret *secret.Secret, expected, next int64) *dynamodb.Put {
+	item := itemFromSecret(namespace, secret)
+	item["version"] = &dynamodb.AttributeValue{
+		N: aws.String(strconv.FormatInt(next, 10)),
+	}
+	item["updated_at"] = &dynamodb.AttributeValue{
+		S: aws.String(now().UTC().Format(time.RFC3339)),
+	}
+
+	condition, names, values := versionCondition(expected)
+
+	return &dynamodb.Put{
+		TableName:                 aws.String(table),
+		Item:                      item,
+		ConditionExpression:       aws.String(condition),
+		ExpressionAttributeNames:  names,
+		ExpressionAttributeValues: values,
+	}
+}
+
+// getItem returns the stored item of the secret with the given key, which is empty if the secret does not exist
+func (c *Client) getItem(table, namespace, key string) (map[string]*dynamodb.AttributeValue, error) {
+	resp, err := c.api.GetItem(&dynamodb.GetItemInput{
+		TableName:      aws.String(table),
+		ConsistentRead: aws.Bool(true),
+		Key: map[string]*dynamodb.AttributeValue{
+			"namespace": &dynamodb.AttributeValue{
+				S: aws.String(namespace),
+			},
+			"key": &dynamodb.AttributeValue{
+				S: aws.String(key),
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Item, nil
+}
+
+// deleteSecret deletes the secret with the given key only if the stored version is the expected one
+// The deleted item is returned.
+func (c *Client) deleteSecret(table, namespace, key string, expected int64) (map[string]*dynamodb.AttributeValue, error) {
+	del := secretDelete(table, namespace, key, expected)
+
+	resp, err := c.api.DeleteItem(&dynamodb.DeleteItemInput{
+		TableName:                 del.TableName,
+		Key:                       del.Key,
+		ConditionExpression:       del.ConditionExpression,
+		ExpressionAttributeNames:  del.ExpressionAttributeNames,
+		ExpressionAttributeValues: del.ExpressionAttributeValues,
+		ReturnValues:              aws.String(dynamodb.ReturnValueAllOld),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Attributes, nil
+}
+
+// secretDelete returns the deletion of the secret with the given key which passes only if the stored version is the expected one
+func secretDelete(table, namespace, key string, expected int64) *dynamodb.Delete {
+	condition, names, values := versionCondition(expected)
+
+	return &dynamodb.Delete{
+		TableName: aws.String(table),
+		Key: map[string]*dynamodb.AttributeValue{
+			"namespace": &dynamodb.AttributeValue{
+				S: aws.String(namespace),
+			},
+			"key": &dynamodb.AttributeValue{
+				S: aws.String(key),
+			},
+		},
+		ConditionExpression:       aws.String(condition),
+		ExpressionAttributeNames:  names,
+		ExpressionAttributeValues: values,
+	}
+}
+
+// archive keeps the given overwritten or deleted item in history
+func (c *Client) archive(table, namespace string, item map[string]*dynamodb.AttributeValue) error {
+	if len(item) == 0 {
+		return nil
+	}
+
+	_, err := c.api.PutItem(&dynamodb.PutItemInput{
+		TableName: aws.String(table),
+		Item:      historyItem(namespace, item),
+	})
+
+	return err
+}
+
+// historyItem returns history item of the given overwritten or deleted item
+// History items are sorted by the time they were archived, because versions restart after deletion.
+func historyItem(namespace string, item map[string]*dynamodb.AttributeValue) map[string]*dynamodb.AttributeValue {
+	history := map[string]*dynamodb.AttributeValue{}
+	for k, v := range item {
+		history[k] = v
+	}
+
+	history["namespace"] = &dynamodb.AttributeValue{
+		S: aws.String(historyPrefix + namespace),
+	}
+	history["key"] = &dynamodb.AttributeValue{
+		S: aws.String(fmt.Sprintf("%s#%020d", *item["key"].S, now().UnixNano())),
+	}
+
+	return history
+}
+
+// trash moves the given deleted item to trash, and returns its ID in trash
+func (c *Client) trash(table, namespace string, item map[string]*dynamodb.AttributeValue) (string, error) {
+	if len(item) == 0 {
+		return "", nil
+	}
+
+	t := trashItem(namespace, item)
+
+	if _, err := c.api.PutItem(&dynamodb.PutItemInput{
+		TableName: aws.String(table),
+		Item:      t,
+	}); err != nil {
+		return "", err
+	}
+
+	return *t["key"].S, nil
+}
+
+// deleteTrash removes the item with the given ID from trash
+func (c *Client) deleteTrash(table, id string) error {
+	del := trashDelete(table, id)
+
+	_, err := c.api.DeleteItem(&dynamodb.DeleteItemInput{
+		TableName: del.TableName,
+		Key:       del.Key,
+	})
+
+	return err
+}
+
+// trashDelete returns the deletion of the item with the given ID from trash
+func trashDelete(table, id string) *dynamodb.Delete {
+	return &dynamodb.Delete{
+		TableName: aws.String(table),
+		Key: map[string]*dynamodb.AttributeValue{
+			"namespace": &dynamodb.AttributeValue{
+				S: aws.String(trashNamespace),
+			},
+			"key": &dynamodb.AttributeValue{
+				S: aws.String(id),
+			},
+		},
+	}
+}
+
+// trashItem returns trash item of the given deleted item
+// Original namespace and key are kept so that the item can be restored, and expires_at is used as TTL attribute.
+func trashItem(namespace string, item map[string]*dynamodb.AttributeValue) map[string]*dynamodb.AttributeValue {
+	deletedAt := now()
+
+	t := map[string]*dynamodb.AttributeValue{}
+	for k, v := range item {
+		t[k] = v
+	}
+
+	t["namespace"] = &dynamodb.AttributeValue{
+		S: aws.String(trashNamespace),
+	}
+	t["key"] = &dynamodb.AttributeValue{
+		S: aws.String(fmt.Sprintf("%s#%s#%020d", namespace, *item["key"].S, deletedAt.UnixNano())),
+	}
+	t["original_namespace"] = &dynamodb.AttributeValue{
+		S: aws.String(namespace),
+	}
+	t["original_key"] = &dynamodb.AttributeValue{
+		S: item["key"].S,
+	}
+	t["deleted_at"] = &dynamodb.AttributeValue{
+		S: aws.String(deletedAt.UTC().Format(time.RFC3339)),
+	}
+	t["expires_at"] = &dynamodb.AttributeValue{
+		N: aws.String(strconv.FormatInt(deletedAt.Add(TrashRetention).Unix(), 10)),
+	}
+
+	return t
+}
+
+// trashedFromItem returns deleted secret of the given trash item
+func trashedFromItem(item map[string]*dynamodb.AttributeValue) *secret.Trashed {
+	t := &secret.Trashed{
+		ID:     *item["key"].S,
+		Secret: secretFromItem(item),
+	}
+
+	if v, ok := item["original_namespace"]; ok && v.S != nil {
+		t.Namespace = *v.S
+	}
+
+	if v, ok := item["original_key"]; ok && v.S != nil {
+		t.Secret.Key = *v.S
+	}
+
+	if v, ok := item["deleted_at"]; ok && v.S != nil {
+		t.DeletedAt, _ = time.Parse(time.RFC3339, *v.S)
+	}
+
+	if v, ok := item["expires_at"]; ok && v.N != nil {
+		if sec, err := strconv.ParseInt(*v.N, 10, 64); err == nil {
+			t.ExpiresAt = time.Unix(sec, 0)
+		}
+	}
+
+	return t
+}
+
+// versionCondition returns condition expression which passes only if the item has the given version
+// Version 0 means that the item does not exist, or was written before version was introduced.
+// Version absent means that the item does not exist at all.
+func versionCondition(version int64) (string, map[string]*string, map[string]*dynamodb.AttributeValue) {
+	if version == absent {
+		return "attribute_not_exists(#key)", map[string]*string{
+			"#key": aws.String("key"),
+		}, nil
+	}
+
+	names := map[string]*string{
+		"#version": aws.String("version"),
+	}
+
+	if version == 0 {
+		return "attribute_not_exists(#version)", names, nil
+	}
+
+	return "#version = :version", names, map[string]*dynamodb.AttributeValue{
+		":version": &dynamodb.AttributeValue{
+			N: aws.String(strconv.FormatInt(version, 10)),
+		},
+	}
+}
+
+func isConditionalCheckFailed(err error) bool {
+	aerr, ok := err.(awserr.Error)
+	return ok && aerr.Code() == "ConditionalCheckFailedException"
+}
+
+func keyOfWriteRequest(wr *dynamodb.WriteRequest) string {
+	var item map[string]*dynamodb.AttributeValue
+
+	if wr.PutRequest != nil {
+		item = wr.PutRequest.Item
+	} else if wr.DeleteRequest != nil {
+		item = wr.DeleteRequest.Key
+	}
+
+	if v, ok := item["key"]; ok && v.S != nil {
+		return *v.S
+	}
+
+	return ""
+}
+
+// queryAll retrieves items of all pages by following LastEvaluatedKey
+func (c *Client) queryAll(params *dynamodb.QueryInput) ([]map[string]*dynamodb.AttributeValue, error) {
+	items := []map[string]*dynamodb.AttributeValue{}
+
+	for {
+		resp, err := c.api.Query(params)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, resp.Items...)
+
+		if len(resp.LastEvaluatedKey) == 0 {
+			break
+		}
+
+		params.ExclusiveStartKey = resp.LastEvaluatedKey
+	}
+
+	return items, nil
+}
+
+func (c *Client) registerNamespace(table, namespace string) error {
+	_, err := c.api.PutItem(&dynamodb.PutItemInput{
+		TableName: aws.String(table),
+		Item:      registryKey(namespace),
+	})
+
+	return err
+}
+
+func (c *Client) deregisterNamespace(table, namespace string) error {
+	_, err := c.api.DeleteItem(&dynamodb.DeleteItemInput{
+		TableName: aws.String(table),
+		Key:       registryKey(namespace),
+	})
+
+	return err
+}
+
+// deregisterIfEmpty removes the namespace from namespace registry if no secret is left in it
+// Namespace is registered again by the next write, even if secrets are written in the meantime.
+func (c *Client) deregisterIfEmpty(table, namespace string) error {
+	resp, err := c.api.Query(&dynamodb.QueryInput{
+		TableName:      aws.String(table),
+		ConsistentRead: aws.Bool(true),
+		Limit:          aws.Int64(1),
+		KeyConditions: map[string]*dynamodb.Condition{
+			"namespace": &dynamodb.Condition{
+				ComparisonOperator: aws.String(dynamodb.ComparisonOperatorEq),
+				AttributeValueList: []*dynamodb.AttributeValue{
+					&dynamodb.AttributeValue{
+						S: aws.String(namespace),
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(resp.Items) > 0 {
+		return nil
+	}
+
+	return c.deregisterNamespace(table, namespace)
+}
+
+func registryKey(namespace string) map[string]*dynamodb.AttributeValue {
+	return map[string]*dynamodb.AttributeValue{
+		"namespace": &dynamodb.AttributeValue{
+			S: aws.String(namespaceRegistry),
+		},
+		"key": &dynamodb.AttributeValue{
+			S: aws.String(namespace),
+		},
+	}
+}
+
+func itemFromSecret(namespace string, secret *secret.Secret) map[string]*dynamodb.AttributeValue {
+	item := map[string]*dynamodb.AttributeValue{
+		"namespace": &dynamodb.AttributeValue{
+			S: aws.String(namespace),
+		},
+		"key": &dynamodb.AttributeValue{
+			S: aws.String(secret.Key),
+		},
+		"value": &dynamodb.AttributeValue{
+			S: aws.String(secret.Value),
+		},
+	}
+
+	if secret.DataKey != "" {
+		item["data_key"] = &dynamodb.AttributeValue{
+			S: aws.String(secret.DataKey),
+		}
+	}
+
+	if secret.Region != "" {
+		item["region"] = &dynamodb.AttributeValue{
+			S: aws.String(secret.Region),
+		}
+	}
+
+	if secret.KeyARN != "" {
+		item["key_arn"] = &dynamodb.AttributeValue{
+			S: aws.String(secret.KeyARN),
+		}
+	}
+
+	if secret.UpdatedBy != "" {
+		item["updated_by"] = &dynamodb.AttributeValue{
+			S: aws.String(secret.UpdatedBy),
+		}
+	}
+
+	if secret.Commit != "" {
+		item["commit"] = &dynamodb.AttributeValue{
+			S: aws.String(secret.Commit),
+		}
+	}
+
+	if len(secret.Replicas) > 0 {
+		replicas := []*dynamodb.AttributeValue{}
+
+		for _, replica := range secret.Replicas {
+			m := map[string]*dynamodb.AttributeValue{
+				"value": &dynamodb.AttributeValue{
+					S: aws.String(replica.Value),
+				},
+			}
+
+			if replica.Region != "" {
+				m["region"] = &dynamodb.AttributeValue{
+					S: aws.String(replica.Region),
+				}
+			}
+
+			if replica.KeyARN != "" {
+				m["key_arn"] = &dynamodb.AttributeValue{
+					S: aws.String(replica.KeyARN),
+				}
+			}
+
+			replicas = append(replicas, &dynamodb.AttributeValue{
+				M: m,
+			})
+		}
+
+		item["replicas"] = &dynamodb.AttributeValue{
+			L: replicas,
+		}
+	}
+
+	return item
+}
+
+// storedItem returns the stored item which the given secret was read from
+// Version and updated_at are kept so that history and trash items are the same as those made from the stored item.
+func storedItem(namespace string, secret *secret.Secret) map[string]*dynamodb.AttributeValue {
+	item := itemFromSecret(namespace, secret)
+
+	if secret.Version > 0 {
+		item["version"] = &dynamodb.AttributeValue{
+			N: aws.String(strconv.FormatInt(secret.Version, 10)),
+		}
+	}
+
+	if !secret.UpdatedAt.IsZero() {
+		item["updated_at"] = &dynamodb.AttributeValue{
+			S: aws.String(secret.UpdatedAt.UTC().Format(time.RFC3339)),
+		}
+	}
+
+	return item
+}
+
+func secretFromItem(item map[string]*dynamodb.AttributeValue) *secret.Secret {
+	s := &secret.Secret{
+		Key:   *item["key"].S,
+		Value: *item["value"].S,
+	}
+
+	if v, ok := item["data_key"]; ok && v.S != nil {
+		s.DataKey = *v.S
+	}
+
+	if v, ok := item["region"]; ok && v.S != nil {
+		s.Region = *v.S
+	}
+
+	if v, ok := item["version"]; ok && v.N != nil {
+		s.Version, _ = strconv.ParseInt(*v.N, 10, 64)
+	}
+
+	if v, ok := item["key_arn"]; ok && v.S != nil {
+		s.KeyARN = *v.S
+	}
+
+	if v, ok := item["updated_at"]; ok && v.S != nil {
+		s.UpdatedAt, _ = time.Parse(time.RFC3339, *v.S)
+	}
+
+	if v, ok := item["updated_by"]; ok && v.S != nil {
+		s.UpdatedBy = *v.S
+	}
+
+	if v, ok := item["commit"]; ok && v.S != nil {
+		s.Commit = *v.S
+	}
+
+	if v, ok := item["replicas"]; ok {
+		for _, r := range v.L {
+			replica := &secret.Replica{}
+
+			if rv, ok := r.M["value"]; ok && rv.S != nil {
+				replica.Value = *rv.S
+			}
+
+			if rr, ok := r.M["region"]; ok && rr.S != nil {
+				replica.Region = *rr.S
+			}
+
+			if rk, ok := r.M["key_arn"]; ok && rk.S != nil {
+				replica.KeyARN = *rk.S
+			}
+
+			s.Replicas = append(s.Replicas, replica)
+		}
+	}
+
+	return s
 }
@@ -1,17 +1,28 @@
 package dynamodb
 
 import (
+	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/dtan4/valec/aws/mock"
 	"github.com/dtan4/valec/secret"
 	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
 )
 
+func init() {
+	now = func() time.Time {
+		return time.Date(2017, 1, 2, 3, 4, 5, 0, time.UTC)
+	}
+}
+
 func TestNewClient(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -24,195 +35,2885 @@ func TestNewClient(t *testing.T) {
 	}
 }
 
-func TestCreateTable(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-
-	api := mock.NewMockDynamoDBAPI(ctrl)
-
-	api.EXPECT().CreateTable(&dynamodb.CreateTableInput{
-		AttributeDefinitions: []*dynamodb.AttributeDefinition{
-			&dynamodb.AttributeDefinition{
-				AttributeName: aws.String("namespace"),
-				AttributeType: aws.String("S"),
-			},
-			&dynamodb.AttributeDefinition{
-				AttributeName: aws.String("key"),
-				AttributeType: aws.String("S"),
-			},
-		},
-		KeySchema: []*dynamodb.KeySchemaElement{
-			&dynamodb.KeySchemaElement{
-				AttributeName: aws.String("namespace"),
-				KeyType:       aws.String("HASH"),
-			},
-			&dynamodb.KeySchemaElement{
-				AttributeName: aws.String("key"),
-				KeyType:       aws.String("RANGE"),
-			},
-		},
-		ProvisionedThroughput: &dynamodb.ProvisionedThroughput{
-			ReadCapacityUnits:  aws.Int64(1),
-			WriteCapacityUnits: aws.Int64(1),
-		},
-		TableName: aws.String("valec"),
-	}).Return(&dynamodb.CreateTableOutput{}, nil)
-	client := &Client{
-		api: api,
-	}
-
-	table := "valec"
-	if err := client.CreateTable(table); err != nil {
-		t.Errorf("Error should not be raised. error: %s", err)
-	}
-}
-
-func TestDelete(t *testing.T) {
+func TestApply(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	api := mock.NewMockDynamoDBAPI(ctrl)
 
-	api.EXPECT().BatchWriteItem(&dynamodb.BatchWriteItemInput{
-		RequestItems: map[string][]*dynamodb.WriteRequest{
-			"valec": []*dynamodb.WriteRequest{
-				&dynamodb.WriteRequest{
-					DeleteRequest: &dynamodb.DeleteRequest{
-						Key: map[string]*dynamodb.AttributeValue{
+	gomock.InOrder(
+		api.EXPECT().TransactWriteItems(&dynamodb.TransactWriteItemsInput{
+			TransactItems: []*dynamodb.TransactWriteItem{
+				&dynamodb.TransactWriteItem{
+					Put: &dynamodb.Put{
+						TableName: aws.String("valec"),
+						Item: map[string]*dynamodb.AttributeValue{
 							"namespace": &dynamodb.AttributeValue{
 								S: aws.String("test"),
 							},
 							"key": &dynamodb.AttributeValue{
-								S: aws.String("BAZ"),
+								S: aws.String("FOO"),
+							},
+							"value": &dynamodb.AttributeValue{
+								S: aws.String("bar"),
+							},
+							"version": &dynamodb.AttributeValue{
+								N: aws.String("3"),
+							},
+							"updated_at": &dynamodb.AttributeValue{
+								S: aws.String("2017-01-02T03:04:05Z"),
+							},
+						},
+						ConditionExpression: aws.String("#version = :version"),
+						ExpressionAttributeNames: map[string]*string{
+							"#version": aws.String("version"),
+						},
+						ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+							":version": &dynamodb.AttributeValue{
+								N: aws.String("2"),
 							},
 						},
 					},
 				},
-				&dynamodb.WriteRequest{
-					DeleteRequest: &dynamodb.DeleteRequest{
+				&dynamodb.TransactWriteItem{
+					Put: &dynamodb.Put{
+						TableName: aws.String("valec"),
+						Item: map[string]*dynamodb.AttributeValue{
+							"namespace": &dynamodb.AttributeValue{
+								S: aws.String(".history/test"),
+							},
+							"key": &dynamodb.AttributeValue{
+								S: aws.String("FOO#01483326245000000000"),
+							},
+							"value": &dynamodb.AttributeValue{
+								S: aws.String("old"),
+							},
+							"version": &dynamodb.AttributeValue{
+								N: aws.String("2"),
+							},
+						},
+					},
+				},
+				&dynamodb.TransactWriteItem{
+					Put: &dynamodb.Put{
+						TableName: aws.String("valec"),
+						Item: map[string]*dynamodb.AttributeValue{
+							"namespace": &dynamodb.AttributeValue{
+								S: aws.String("test"),
+							},
+							"key": &dynamodb.AttributeValue{
+								S: aws.String("BAR"),
+							},
+							"value": &dynamodb.AttributeValue{
+								S: aws.String("fuga"),
+							},
+							"version": &dynamodb.AttributeValue{
+								N: aws.String("1"),
+							},
+							"updated_at": &dynamodb.AttributeValue{
+								S: aws.String("2017-01-02T03:04:05Z"),
+							},
+						},
+						ConditionExpression: aws.String("attribute_not_exists(#version)"),
+						ExpressionAttributeNames: map[string]*string{
+							"#version": aws.String("version"),
+						},
+					},
+				},
+				&dynamodb.TransactWriteItem{
+					Delete: &dynamodb.Delete{
+						TableName: aws.String("valec"),
 						Key: map[string]*dynamodb.AttributeValue{
 							"namespace": &dynamodb.AttributeValue{
 								S: aws.String("test"),
 							},
 							"key": &dynamodb.AttributeValue{
-								S: aws.String("FOO"),
+								S: aws.String("BAZ"),
+							},
+						},
+						ConditionExpression: aws.String("#version = :version"),
+						ExpressionAttributeNames: map[string]*string{
+							"#version": aws.String("version"),
+						},
+						ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+							":version": &dynamodb.AttributeValue{
+								N: aws.String("1"),
+							},
+						},
+					},
+				},
+				&dynamodb.TransactWriteItem{
+					Put: &dynamodb.Put{
+						TableName: aws.String("valec"),
+						Item: map[string]*dynamodb.AttributeValue{
+							"namespace": &dynamodb.AttributeValue{
+								S: aws.String(".history/test"),
+							},
+							"key": &dynamodb.AttributeValue{
+								S: aws.String("BAZ#01483326245000000000"),
+							},
+							"value": &dynamodb.AttributeValue{
+								S: aws.String("1"),
+							},
+							"version": &dynamodb.AttributeValue{
+								N: aws.String("1"),
+							},
+							"updated_at": &dynamodb.AttributeValue{
+								S: aws.String("2017-01-01T00:00:00Z"),
+							},
+						},
+					},
+				},
+				&dynamodb.TransactWriteItem{
+					Put: &dynamodb.Put{
+						TableName: aws.String("valec"),
+						Item: map[string]*dynamodb.AttributeValue{
+							"namespace": &dynamodb.AttributeValue{
+								S: aws.String(".trash"),
+							},
+							"key": &dynamodb.AttributeValue{
+								S: aws.String("test#BAZ#01483326245000000000"),
+							},
+							"value": &dynamodb.AttributeValue{
+								S: aws.String("1"),
+							},
+							"version": &dynamodb.AttributeValue{
+								N: aws.String("1"),
+							},
+							"updated_at": &dynamodb.AttributeValue{
+								S: aws.String("2017-01-01T00:00:00Z"),
+							},
+							"original_namespace": &dynamodb.AttributeValue{
+								S: aws.String("test"),
+							},
+							"original_key": &dynamodb.AttributeValue{
+								S: aws.String("BAZ"),
+							},
+							"deleted_at": &dynamodb.AttributeValue{
+								S: aws.String("2017-01-02T03:04:05Z"),
+							},
+							"expires_at": &dynamodb.AttributeValue{
+								N: aws.String("1485918245"),
 							},
 						},
 					},
 				},
 			},
-		},
-	}).Return(&dynamodb.BatchWriteItemOutput{}, nil)
+		}).Return(&dynamodb.TransactWriteItemsOutput{}, nil),
+		api.EXPECT().PutItem(&dynamodb.PutItemInput{
+			TableName: aws.String("valec"),
+			Item: map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String(".namespaces"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("test"),
+				},
+			},
+		}).Return(&dynamodb.PutItemOutput{}, nil),
+	)
 	client := &Client{
 		api: api,
 	}
 
-	secrets := []*secret.Secret{
+	puts := []*secret.Secret{
 		&secret.Secret{
-			Key:   "BAZ",
-			Value: "1",
+			Key:     "FOO",
+			Value:   "bar",
+			Version: 2,
 		},
 		&secret.Secret{
-			Key:   "FOO",
-			Value: "bar",
+			Key:   "BAR",
+			Value: "fuga",
+		},
+	}
+	deletes := []*secret.Secret{
+		&secret.Secret{
+			Key:       "BAZ",
+			Value:     "1",
+			Version:   1,
+			UpdatedAt: time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	originals := []*secret.Secret{
+		&secret.Secret{
+			Key:     "FOO",
+			Value:   "old",
+			Version: 2,
 		},
 	}
 
 	table := "valec"
 	namespace := "test"
-	if err := client.Delete(table, namespace, secrets); err != nil {
+	if err := client.Apply(table, namespace, puts, deletes, originals); err != nil {
 		t.Errorf("Error should not be raised. error: %s", err)
 	}
 }
 
-func TestDelete_30items(t *testing.T) {
+func TestApply_conflict(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	writeRequests1 := []*dynamodb.WriteRequest{}
+	api := mock.NewMockDynamoDBAPI(ctrl)
 
-	for i := 0; i < 25; i++ {
-		writeRequests1 = append(writeRequests1, &dynamodb.WriteRequest{
-			DeleteRequest: &dynamodb.DeleteRequest{
-				Key: map[string]*dynamodb.AttributeValue{
-					"namespace": &dynamodb.AttributeValue{
-						S: aws.String("test"),
-					},
-					"key": &dynamodb.AttributeValue{
-						S: aws.String("BAZ" + strconv.Itoa(i)),
-					},
-				},
-			},
-		})
+	// FOO (put and history), BAR (put), BAZ (delete, history and trash)
+	api.EXPECT().TransactWriteItems(gomock.Any()).Do(func(input *dynamodb.TransactWriteItemsInput) {
+		if len(input.TransactItems) != 6 {
+			t.Errorf("Number of items does not match. expected: 6, actual: %d", len(input.TransactItems))
+		}
+	}).Return(nil, awserr.New("TransactionCanceledException", "Transaction cancelled, please refer cancellation reasons for specific reasons [None, None, None, ConditionalCheckFailed, None, None]", nil))
+	client := &Client{
+		api: api,
 	}
 
-	writeRequests2 := []*dynamodb.WriteRequest{}
+	puts := []*secret.Secret{
+		&secret.Secret{
+			Key:     "FOO",
+			Value:   "bar",
+			Version: 2,
+		},
+		&secret.Secret{
+			Key:   "BAR",
+			Value: "fuga",
+		},
+	}
+	deletes := []*secret.Secret{
+		&secret.Secret{
+			Key:     "BAZ",
+			Value:   "1",
+			Version: 1,
+		},
+	}
+	originals := []*secret.Secret{
+		&secret.Secret{
+			Key:     "FOO",
+			Value:   "old",
+			Version: 2,
+		},
+	}
 
-	for i := 25; i < 30; i++ {
-		writeRequests2 = append(writeRequests2, &dynamodb.WriteRequest{
-			DeleteRequest: &dynamodb.DeleteRequest{
-				Key: map[string]*dynamodb.AttributeValue{
-					"namespace": &dynamodb.AttributeValue{
-						S: aws.String("test"),
-					},
-					"key": &dynamodb.AttributeValue{
-						S: aws.String("BAZ" + strconv.Itoa(i)),
-					},
-				},
-			},
-		})
+	table := "valec"
+	namespace := "test"
+	err := client.Apply(table, namespace, puts, deletes, originals)
+	if err == nil {
+		t.Fatalf("Error should be raised.")
 	}
 
+	conflict, ok := errors.Cause(err).(*secret.ConflictError)
+	if !ok {
+		t.Fatalf("ConflictError should be raised. error: %s", err)
+	}
+
+	if !reflect.DeepEqual(conflict.Keys, []string{"BAZ"}) {
+		t.Errorf("Conflicted keys do not match. expected: %q, actual: %q", []string{"BAZ"}, conflict.Keys)
+	}
+
+	expected := "No change was applied.: Secrets were changed by someone else. namespace=test, keys=BAZ"
+	if err.Error() != expected {
+		t.Errorf("Error message does not match. expected: %q, actual: %q", expected, err.Error())
+	}
+}
+
+func TestApply_revert(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
 	api := mock.NewMockDynamoDBAPI(ctrl)
-	api.EXPECT().BatchWriteItem(&dynamodb.BatchWriteItemInput{
-		RequestItems: map[string][]*dynamodb.WriteRequest{
-			"valec": writeRequests1,
-		},
-	}).Return(&dynamodb.BatchWriteItemOutput{}, nil)
-	api.EXPECT().BatchWriteItem(&dynamodb.BatchWriteItemInput{
-		RequestItems: map[string][]*dynamodb.WriteRequest{
-			"valec": writeRequests2,
-		},
-	}).Return(&dynamodb.BatchWriteItemOutput{}, nil)
+
+	// 34 deletions need 102 items, which are split into 99 items and 3 items
+	gomock.InOrder(
+		api.EXPECT().TransactWriteItems(gomock.Any()).Do(func(input *dynamodb.TransactWriteItemsInput) {
+			if len(input.TransactItems) != 99 {
+				t.Errorf("Number of items does not match. expected: 99, actual: %d", len(input.TransactItems))
+			}
+		}).Return(&dynamodb.TransactWriteItemsOutput{}, nil),
+		api.EXPECT().TransactWriteItems(gomock.Any()).Do(func(input *dynamodb.TransactWriteItemsInput) {
+			if len(input.TransactItems) != 3 {
+				t.Errorf("Number of items does not match. expected: 3, actual: %d", len(input.TransactItems))
+			}
+		}).Return(nil, fmt.Errorf("InternalServerError")),
+		// Deleted secrets are written back only if no one has created them meanwhile, and removed from trash
+		api.EXPECT().TransactWriteItems(gomock.Any()).Do(func(input *dynamodb.TransactWriteItemsInput) {
+			if len(input.TransactItems) != 66 {
+				t.Fatalf("Number of items does not match. expected: 66, actual: %d", len(input.TransactItems))
+			}
+
+			put := input.TransactItems[0].Put
+			if put == nil || *put.Item["key"].S != "KEY00" || *put.ConditionExpression != "attribute_not_exists(#key)" {
+				t.Errorf("Deleted secret should be written back. actual: %s", input.TransactItems[0])
+			}
+
+			del := input.TransactItems[1].Delete
+			if del == nil || *del.Key["namespace"].S != ".trash" || *del.Key["key"].S != "test#KEY00#01483326245000000000" {
+				t.Errorf("Deleted secret should be removed from trash. actual: %s", input.TransactItems[1])
+			}
+		}).Return(&dynamodb.TransactWriteItemsOutput{}, nil),
+	)
 	client := &Client{
 		api: api,
 	}
 
-	secrets := []*secret.Secret{}
-
-	for i := 0; i < 30; i++ {
-		secrets = append(secrets, &secret.Secret{
-			Key:   "BAZ" + strconv.Itoa(i),
-			Value: strconv.Itoa(i),
+	deletes := []*secret.Secret{}
+	for i := 0; i < 34; i++ {
+		deletes = append(deletes, &secret.Secret{
+			Key:     fmt.Sprintf("KEY%02d", i),
+			Value:   strconv.Itoa(i),
+			Version: 1,
 		})
 	}
 
 	table := "valec"
 	namespace := "test"
-	if err := client.Delete(table, namespace, secrets); err != nil {
-		t.Errorf("Error should not be raised. error: %s", err)
+	err := client.Apply(table, namespace, []*secret.Secret{}, deletes, []*secret.Secret{})
+	if err == nil {
+		t.Fatalf("Error should be raised.")
+	}
+
+	expected := "Changes were reverted. reverted=33: InternalServerError"
+	if err.Error() != expected {
+		t.Errorf("Error message does not match. expected: %q, actual: %q", expected, err.Error())
 	}
 }
 
-func TestDelete_nosecret(t *testing.T) {
+func TestApply_revertFailed(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	api := mock.NewMockDynamoDBAPI(ctrl)
+
+	// 101 writes of new secrets are split into 100 items and 1 item
+	gomock.InOrder(
+		api.EXPECT().TransactWriteItems(gomock.Any()).Return(&dynamodb.TransactWriteItemsOutput{}, nil),
+		api.EXPECT().TransactWriteItems(gomock.Any()).Return(nil, awserr.New("TransactionCanceledException", "Transaction cancelled, please refer cancellation reasons for specific reasons [ConditionalCheckFailed]", nil)),
+		api.EXPECT().TransactWriteItems(gomock.Any()).Return(nil, fmt.Errorf("InternalServerError")),
+	)
 	client := &Client{
 		api: api,
 	}
 
+	puts := []*secret.Secret{}
+	committed := []string{}
+	for i := 0; i < 101; i++ {
+		puts = append(puts, &secret.Secret{
+			Key:   fmt.Sprintf("KEY%03d", i),
+			Value: strconv.Itoa(i),
+		})
+
+		if i < 100 {
+			committed = append(committed, fmt.Sprintf("KEY%03d", i))
+		}
+	}
+
 	table := "valec"
 	namespace := "test"
-	secrets := []*secret.Secret{}
+	err := client.Apply(table, namespace, puts, []*secret.Secret{}, []*secret.Secret{})
+	if err == nil {
+		t.Fatalf("Error should be raised.")
+	}
 
-	if err := client.Delete(table, namespace, secrets); err != nil {
+	conflict, ok := errors.Cause(err).(*secret.ConflictError)
+	if !ok {
+		t.Fatalf("ConflictError should be raised. error: %s", err)
+	}
+
+	if !reflect.DeepEqual(conflict.Keys, []string{"KEY100"}) {
+		t.Errorf("Conflicted keys do not match. expected: %q, actual: %q", []string{"KEY100"}, conflict.Keys)
+	}
+
+	expected := "Failed to revert changes. Please check these secrets. reverted=0, keys=" + strings.Join(committed, ",") + ": Secrets were changed by someone else. namespace=test, keys=KEY100"
+	if err.Error() != expected {
+		t.Errorf("Error message does not match. expected: %q, actual: %q", expected, err.Error())
+	}
+}
+
+func TestCreateTable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := mock.NewMockDynamoDBAPI(ctrl)
+
+	api.EXPECT().CreateTable(&dynamodb.CreateTableInput{
+		AttributeDefinitions: []*dynamodb.AttributeDefinition{
+			&dynamodb.AttributeDefinition{
+				AttributeName: aws.String("namespace"),
+				AttributeType: aws.String("S"),
+			},
+			&dynamodb.AttributeDefinition{
+				AttributeName: aws.String("key"),
+				AttributeType: aws.String("S"),
+			},
+		},
+		KeySchema: []*dynamodb.KeySchemaElement{
+			&dynamodb.KeySchemaElement{
+				AttributeName: aws.String("namespace"),
+				KeyType:       aws.String("HASH"),
+			},
+			&dynamodb.KeySchemaElement{
+				AttributeName: aws.String("key"),
+				KeyType:       aws.String("RANGE"),
+			},
+		},
+		ProvisionedThroughput: &dynamodb.ProvisionedThroughput{
+			ReadCapacityUnits:  aws.Int64(1),
+			WriteCapacityUnits: aws.Int64(1),
+		},
+		TableName: aws.String("valec"),
+	}).Return(&dynamodb.CreateTableOutput{}, nil)
+	api.EXPECT().WaitUntilTableExists(&dynamodb.DescribeTableInput{
+		TableName: aws.String("valec"),
+	}).Return(nil)
+	api.EXPECT().PutItem(&dynamodb.PutItemInput{
+		TableName: aws.String("valec"),
+		Item: map[string]*dynamodb.AttributeValue{
+			"namespace": &dynamodb.AttributeValue{
+				S: aws.String(".namespaces"),
+			},
+			"key": &dynamodb.AttributeValue{
+				S: aws.String(".migrated"),
+			},
+		},
+	}).Return(&dynamodb.PutItemOutput{}, nil)
+	client := &Client{
+		api: api,
+	}
+
+	table := "valec"
+	if err := client.CreateTable(table); err != nil {
+		t.Errorf("Error should not be raised. error: %s", err)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := mock.NewMockDynamoDBAPI(ctrl)
+
+	gomock.InOrder(
+		api.EXPECT().GetItem(&dynamodb.GetItemInput{
+			TableName:      aws.String("valec"),
+			ConsistentRead: aws.Bool(true),
+			Key: map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String("test"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("BAZ"),
+				},
+			},
+		}).Return(&dynamodb.GetItemOutput{}, nil),
+		api.EXPECT().DeleteItem(&dynamodb.DeleteItemInput{
+			TableName: aws.String("valec"),
+			Key: map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String("test"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("BAZ"),
+				},
+			},
+			ConditionExpression: aws.String("attribute_not_exists(#version)"),
+			ExpressionAttributeNames: map[string]*string{
+				"#version": aws.String("version"),
+			},
+			ReturnValues: aws.String("ALL_OLD"),
+		}).Return(&dynamodb.DeleteItemOutput{}, nil),
+		api.EXPECT().GetItem(&dynamodb.GetItemInput{
+			TableName:      aws.String("valec"),
+			ConsistentRead: aws.Bool(true),
+			Key: map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String("test"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("FOO"),
+				},
+			},
+		}).Return(&dynamodb.GetItemOutput{
+			Item: map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String("test"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("FOO"),
+				},
+				"value": &dynamodb.AttributeValue{
+					S: aws.String("bar"),
+				},
+				"version": &dynamodb.AttributeValue{
+					N: aws.String("2"),
+				},
+			},
+		}, nil),
+		api.EXPECT().PutItem(&dynamodb.PutItemInput{
+			TableName: aws.String("valec"),
+			Item: map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String(".history/test"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("FOO#01483326245000000000"),
+				},
+				"value": &dynamodb.AttributeValue{
+					S: aws.String("bar"),
+				},
+				"version": &dynamodb.AttributeValue{
+					N: aws.String("2"),
+				},
+			},
+		}).Return(&dynamodb.PutItemOutput{}, nil),
+		api.EXPECT().PutItem(&dynamodb.PutItemInput{
+			TableName: aws.String("valec"),
+			Item: map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String(".trash"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("test#FOO#01483326245000000000"),
+				},
+				"value": &dynamodb.AttributeValue{
+					S: aws.String("bar"),
+				},
+				"version": &dynamodb.AttributeValue{
+					N: aws.String("2"),
+				},
+				"original_namespace": &dynamodb.AttributeValue{
+					S: aws.String("test"),
+				},
+				"original_key": &dynamodb.AttributeValue{
+					S: aws.String("FOO"),
+				},
+				"deleted_at": &dynamodb.AttributeValue{
+					S: aws.String("2017-01-02T03:04:05Z"),
+				},
+				"expires_at": &dynamodb.AttributeValue{
+					N: aws.String("1485918245"),
+				},
+			},
+		}).Return(&dynamodb.PutItemOutput{}, nil),
+		api.EXPECT().DeleteItem(&dynamodb.DeleteItemInput{
+			TableName: aws.String("valec"),
+			Key: map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String("test"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("FOO"),
+				},
+			},
+			ConditionExpression: aws.String("#version = :version"),
+			ExpressionAttributeNames: map[string]*string{
+				"#version": aws.String("version"),
+			},
+			ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+				":version": &dynamodb.AttributeValue{
+					N: aws.String("2"),
+				},
+			},
+			ReturnValues: aws.String("ALL_OLD"),
+		}).Return(&dynamodb.DeleteItemOutput{}, nil),
+		api.EXPECT().Query(&dynamodb.QueryInput{
+			TableName:      aws.String("valec"),
+			ConsistentRead: aws.Bool(true),
+			Limit:          aws.Int64(1),
+			KeyConditions: map[string]*dynamodb.Condition{
+				"namespace": &dynamodb.Condition{
+					ComparisonOperator: aws.String(dynamodb.ComparisonOperatorEq),
+					AttributeValueList: []*dynamodb.AttributeValue{
+						&dynamodb.AttributeValue{
+							S: aws.String("test"),
+						},
+					},
+				},
+			},
+		}).Return(&dynamodb.QueryOutput{}, nil),
+		api.EXPECT().DeleteItem(&dynamodb.DeleteItemInput{
+			TableName: aws.String("valec"),
+			Key: map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String(".namespaces"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("test"),
+				},
+			},
+		}).Return(&dynamodb.DeleteItemOutput{}, nil),
+	)
+	client := &Client{
+		api: api,
+	}
+
+	secrets := []*secret.Secret{
+		&secret.Secret{
+			Key:   "BAZ",
+			Value: "1",
+		},
+		&secret.Secret{
+			Key:     "FOO",
+			Value:   "bar",
+			Version: 2,
+		},
+	}
+
+	table := "valec"
+	namespace := "test"
+	if err := client.Delete(table, namespace, secrets); err != nil {
+		t.Errorf("Error should not be raised. error: %s", err)
+	}
+}
+
+func TestDelete_conflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := mock.NewMockDynamoDBAPI(ctrl)
+
+	gomock.InOrder(
+		api.EXPECT().GetItem(&dynamodb.GetItemInput{
+			TableName:      aws.String("valec"),
+			ConsistentRead: aws.Bool(true),
+			Key: map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String("test"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("BAZ"),
+				},
+			},
+		}).Return(&dynamodb.GetItemOutput{
+			Item: map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String("test"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("BAZ"),
+				},
+				"value": &dynamodb.AttributeValue{
+					S: aws.String("1"),
+				},
+				"version": &dynamodb.AttributeValue{
+					N: aws.String("1"),
+				},
+			},
+		}, nil),
+		api.EXPECT().PutItem(&dynamodb.PutItemInput{
+			TableName: aws.String("valec"),
+			Item: map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String(".history/test"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("BAZ#01483326245000000000"),
+				},
+				"value": &dynamodb.AttributeValue{
+					S: aws.String("1"),
+				},
+				"version": &dynamodb.AttributeValue{
+					N: aws.String("1"),
+				},
+			},
+		}).Return(&dynamodb.PutItemOutput{}, nil),
+		api.EXPECT().PutItem(gomock.Any()).Return(&dynamodb.PutItemOutput{}, nil),
+		api.EXPECT().DeleteItem(&dynamodb.DeleteItemInput{
+			TableName: aws.String("valec"),
+			Key: map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String("test"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("BAZ"),
+				},
+			},
+			ConditionExpression: aws.String("#version = :version"),
+			ExpressionAttributeNames: map[string]*string{
+				"#version": aws.String("version"),
+			},
+			ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+				":version": &dynamodb.AttributeValue{
+					N: aws.String("1"),
+				},
+			},
+			ReturnValues: aws.String("ALL_OLD"),
+		}).Return(nil, awserr.New("ConditionalCheckFailedException", "The conditional request failed", nil)),
+		api.EXPECT().DeleteItem(&dynamodb.DeleteItemInput{
+			TableName: aws.String("valec"),
+			Key: map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String(".trash"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("test#BAZ#01483326245000000000"),
+				},
+			},
+		}).Return(&dynamodb.DeleteItemOutput{}, nil),
+		api.EXPECT().GetItem(&dynamodb.GetItemInput{
+			TableName:      aws.String("valec"),
+			ConsistentRead: aws.Bool(true),
+			Key: map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String("test"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("FOO"),
+				},
+			},
+		}).Return(&dynamodb.GetItemOutput{
+			Item: map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String("test"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("FOO"),
+				},
+				"value": &dynamodb.AttributeValue{
+					S: aws.String("baz"),
+				},
+				"version": &dynamodb.AttributeValue{
+					N: aws.String("3"),
+				},
+			},
+		}, nil),
+		api.EXPECT().Query(&dynamodb.QueryInput{
+			TableName:      aws.String("valec"),
+			ConsistentRead: aws.Bool(true),
+			Limit:          aws.Int64(1),
+			KeyConditions: map[string]*dynamodb.Condition{
+				"namespace": &dynamodb.Condition{
+					ComparisonOperator: aws.String(dynamodb.ComparisonOperatorEq),
+					AttributeValueList: []*dynamodb.AttributeValue{
+						&dynamodb.AttributeValue{
+							S: aws.String("test"),
+						},
+					},
+				},
+			},
+		}).Return(&dynamodb.QueryOutput{
+			Items: []map[string]*dynamodb.AttributeValue{
+				map[string]*dynamodb.AttributeValue{
+					"namespace": &dynamodb.AttributeValue{
+						S: aws.String("test"),
+					},
+					"key": &dynamodb.AttributeValue{
+						S: aws.String("BAZ"),
+					},
+				},
+			},
+		}, nil),
+	)
+	client := &Client{
+		api: api,
+	}
+
+	secrets := []*secret.Secret{
+		&secret.Secret{
+			Key:     "BAZ",
+			Value:   "1",
+			Version: 1,
+		},
+		&secret.Secret{
+			Key:     "FOO",
+			Value:   "bar",
+			Version: 2,
+		},
+	}
+
+	table := "valec"
+	namespace := "test"
+	err := client.Delete(table, namespace, secrets)
+	if err == nil {
+		t.Fatalf("Error should be raised.")
+	}
+
+	conflict, ok := errors.Cause(err).(*secret.ConflictError)
+	if !ok {
+		t.Fatalf("ConflictError should be raised. error: %s", err)
+	}
+
+	expected := &secret.ConflictError{
+		Namespace: "test",
+		Keys:      []string{"BAZ", "FOO"},
+	}
+	if !reflect.DeepEqual(conflict, expected) {
+		t.Errorf("Conflict does not match. expected: %#v, actual: %#v", expected, conflict)
+	}
+}
+
+func TestDelete_trashFailed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := mock.NewMockDynamoDBAPI(ctrl)
+
+	// DeleteItem must not be called, so that the item survives
+	gomock.InOrder(
+		api.EXPECT().GetItem(gomock.Any()).Return(&dynamodb.GetItemOutput{
+			Item: map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String("test"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("FOO"),
+				},
+				"value": &dynamodb.AttributeValue{
+					S: aws.String("bar"),
+				},
+				"version": &dynamodb.AttributeValue{
+					N: aws.String("2"),
+				},
+			},
+		}, nil),
+		api.EXPECT().PutItem(gomock.Any()).Return(&dynamodb.PutItemOutput{}, nil),
+		api.EXPECT().PutItem(gomock.Any()).Return(nil, fmt.Errorf("ProvisionedThroughputExceededException")),
+	)
+	client := &Client{
+		api: api,
+	}
+
+	secrets := []*secret.Secret{
+		&secret.Secret{
+			Key:     "FOO",
+			Value:   "bar",
+			Version: 2,
+		},
+	}
+
+	table := "valec"
+	namespace := "test"
+	if err := client.Delete(table, namespace, secrets); err == nil {
+		t.Errorf("Error should be raised.")
+	}
+}
+
+func TestDelete_nosecret(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := mock.NewMockDynamoDBAPI(ctrl)
+	client := &Client{
+		api: api,
+	}
+
+	table := "valec"
+	namespace := "test"
+	secrets := []*secret.Secret{}
+
+	if err := client.Delete(table, namespace, secrets); err != nil {
+		t.Errorf("Error should not be raised. error: %s", err)
+	}
+}
+
+func TestDeleteNamespace(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := mock.NewMockDynamoDBAPI(ctrl)
+
+	api.EXPECT().Query(&dynamodb.QueryInput{
+		TableName: aws.String("valec"),
+		KeyConditions: map[string]*dynamodb.Condition{
+			"namespace": &dynamodb.Condition{
+				ComparisonOperator: aws.String(dynamodb.ComparisonOperatorEq),
+				AttributeValueList: []*dynamodb.AttributeValue{
+					&dynamodb.AttributeValue{
+						S: aws.String("test"),
+					},
+				},
+			},
+		},
+	}).Return(&dynamodb.QueryOutput{
+		Items: []map[string]*dynamodb.AttributeValue{
+			map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String("test"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("BAZ"),
+				},
+				"value": &dynamodb.AttributeValue{
+					S: aws.String("1"),
+				},
+			},
+			map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String("test"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("FOO"),
+				},
+				"value": &dynamodb.AttributeValue{
+					S: aws.String("bar"),
+				},
+			},
+			map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String("test"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("BAR"),
+				},
+				"value": &dynamodb.AttributeValue{
+					S: aws.String("fuga"),
+				},
+			},
+		},
+	}, nil)
+	api.EXPECT().BatchWriteItem(&dynamodb.BatchWriteItemInput{
+		RequestItems: map[string][]*dynamodb.WriteRequest{
+			"valec": []*dynamodb.WriteRequest{
+				&dynamodb.WriteRequest{
+					PutRequest: &dynamodb.PutRequest{
+						Item: map[string]*dynamodb.AttributeValue{
+							"namespace": &dynamodb.AttributeValue{
+								S: aws.String(".trash"),
+							},
+							"key": &dynamodb.AttributeValue{
+								S: aws.String("test#BAZ#01483326245000000000"),
+							},
+							"value": &dynamodb.AttributeValue{
+								S: aws.String("1"),
+							},
+							"original_namespace": &dynamodb.AttributeValue{
+								S: aws.String("test"),
+							},
+							"original_key": &dynamodb.AttributeValue{
+								S: aws.String("BAZ"),
+							},
+							"deleted_at": &dynamodb.AttributeValue{
+								S: aws.String("2017-01-02T03:04:05Z"),
+							},
+							"expires_at": &dynamodb.AttributeValue{
+								N: aws.String("1485918245"),
+							},
+						},
+					},
+				},
+				&dynamodb.WriteRequest{
+					PutRequest: &dynamodb.PutRequest{
+						Item: map[string]*dynamodb.AttributeValue{
+							"namespace": &dynamodb.AttributeValue{
+								S: aws.String(".trash"),
+							},
+							"key": &dynamodb.AttributeValue{
+								S: aws.String("test#FOO#01483326245000000000"),
+							},
+							"value": &dynamodb.AttributeValue{
+								S: aws.String("bar"),
+							},
+							"original_namespace": &dynamodb.AttributeValue{
+								S: aws.String("test"),
+							},
+							"original_key": &dynamodb.AttributeValue{
+								S: aws.String("FOO"),
+							},
+							"deleted_at": &dynamodb.AttributeValue{
+								S: aws.String("2017-01-02T03:04:05Z"),
+							},
+							"expires_at": &dynamodb.AttributeValue{
+								N: aws.String("1485918245"),
+							},
+						},
+					},
+				},
+				&dynamodb.WriteRequest{
+					PutRequest: &dynamodb.PutRequest{
+						Item: map[string]*dynamodb.AttributeValue{
+							"namespace": &dynamodb.AttributeValue{
+								S: aws.String(".trash"),
+							},
+							"key": &dynamodb.AttributeValue{
+								S: aws.String("test#BAR#01483326245000000000"),
+							},
+							"value": &dynamodb.AttributeValue{
+								S: aws.String("fuga"),
+							},
+							"original_namespace": &dynamodb.AttributeValue{
+								S: aws.String("test"),
+							},
+							"original_key": &dynamodb.AttributeValue{
+								S: aws.String("BAR"),
+							},
+							"deleted_at": &dynamodb.AttributeValue{
+								S: aws.String("2017-01-02T03:04:05Z"),
+							},
+							"expires_at": &dynamodb.AttributeValue{
+								N: aws.String("1485918245"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}).Return(&dynamodb.BatchWriteItemOutput{}, nil)
+	api.EXPECT().BatchWriteItem(&dynamodb.BatchWriteItemInput{
+		RequestItems: map[string][]*dynamodb.WriteRequest{
+			"valec": []*dynamodb.WriteRequest{
+				&dynamodb.WriteRequest{
+					DeleteRequest: &dynamodb.DeleteRequest{
+						Key: map[string]*dynamodb.AttributeValue{
+							"namespace": &dynamodb.AttributeValue{
+								S: aws.String("test"),
+							},
+							"key": &dynamodb.AttributeValue{
+								S: aws.String("BAZ"),
+							},
+						},
+					},
+				},
+				&dynamodb.WriteRequest{
+					DeleteRequest: &dynamodb.DeleteRequest{
+						Key: map[string]*dynamodb.AttributeValue{
+							"namespace": &dynamodb.AttributeValue{
+								S: aws.String("test"),
+							},
+							"key": &dynamodb.AttributeValue{
+								S: aws.String("FOO"),
+							},
+						},
+					},
+				},
+				&dynamodb.WriteRequest{
+					DeleteRequest: &dynamodb.DeleteRequest{
+						Key: map[string]*dynamodb.AttributeValue{
+							"namespace": &dynamodb.AttributeValue{
+								S: aws.String("test"),
+							},
+							"key": &dynamodb.AttributeValue{
+								S: aws.String("BAR"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}).Return(&dynamodb.BatchWriteItemOutput{}, nil)
+	api.EXPECT().DeleteItem(&dynamodb.DeleteItemInput{
+		TableName: aws.String("valec"),
+		Key: map[string]*dynamodb.AttributeValue{
+			"namespace": &dynamodb.AttributeValue{
+				S: aws.String(".namespaces"),
+			},
+			"key": &dynamodb.AttributeValue{
+				S: aws.String("test"),
+			},
+		},
+	}).Return(&dynamodb.DeleteItemOutput{}, nil)
+	client := &Client{
+		api: api,
+	}
+
+	table := "valec"
+	namespace := "test"
+	if err := client.DeleteNamespace(table, namespace); err != nil {
+		t.Errorf("Error should not be raised. error: %s", err)
+	}
+}
+
+func TestDeleteNamespace_30items(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	items := []map[string]*dynamodb.AttributeValue{}
+
+	for i := 0; i < 30; i++ {
+		items = append(items, map[string]*dynamodb.AttributeValue{
+			"namespace": &dynamodb.AttributeValue{
+				S: aws.String("test"),
+			},
+			"key": &dynamodb.AttributeValue{
+				S: aws.String("BAZ" + strconv.Itoa(i)),
+			},
+			"value": &dynamodb.AttributeValue{
+				S: aws.String(strconv.Itoa(i)),
+			},
+		})
+	}
+
+	trashRequests1, trashRequests2 := []*dynamodb.WriteRequest{}, []*dynamodb.WriteRequest{}
+
+	for i := 0; i < 30; i++ {
+		wr := &dynamodb.WriteRequest{
+			PutRequest: &dynamodb.PutRequest{
+				Item: map[string]*dynamodb.AttributeValue{
+					"namespace": &dynamodb.AttributeValue{
+						S: aws.String(".trash"),
+					},
+					"key": &dynamodb.AttributeValue{
+						S: aws.String("test#BAZ" + strconv.Itoa(i) + "#01483326245000000000"),
+					},
+					"value": &dynamodb.AttributeValue{
+						S: aws.String(strconv.Itoa(i)),
+					},
+					"original_namespace": &dynamodb.AttributeValue{
+						S: aws.String("test"),
+					},
+					"original_key": &dynamodb.AttributeValue{
+						S: aws.String("BAZ" + strconv.Itoa(i)),
+					},
+					"deleted_at": &dynamodb.AttributeValue{
+						S: aws.String("2017-01-02T03:04:05Z"),
+					},
+					"expires_at": &dynamodb.AttributeValue{
+						N: aws.String("1485918245"),
+					},
+				},
+			},
+		}
+
+		if i < 25 {
+			trashRequests1 = append(trashRequests1, wr)
+		} else {
+			trashRequests2 = append(trashRequests2, wr)
+		}
+	}
+
+	writeRequests1 := []*dynamodb.WriteRequest{}
+
+	for i := 0; i < 25; i++ {
+		writeRequests1 = append(writeRequests1, &dynamodb.WriteRequest{
+			DeleteRequest: &dynamodb.DeleteRequest{
+				Key: map[string]*dynamodb.AttributeValue{
+					"namespace": &dynamodb.AttributeValue{
+						S: aws.String("test"),
+					},
+					"key": &dynamodb.AttributeValue{
+						S: aws.String("BAZ" + strconv.Itoa(i)),
+					},
+				},
+			},
+		})
+	}
+
+	writeRequests2 := []*dynamodb.WriteRequest{}
+
+	for i := 25; i < 30; i++ {
+		writeRequests2 = append(writeRequests2, &dynamodb.WriteRequest{
+			DeleteRequest: &dynamodb.DeleteRequest{
+				Key: map[string]*dynamodb.AttributeValue{
+					"namespace": &dynamodb.AttributeValue{
+						S: aws.String("test"),
+					},
+					"key": &dynamodb.AttributeValue{
+						S: aws.String("BAZ" + strconv.Itoa(i)),
+					},
+				},
+			},
+		})
+	}
+
+	api := mock.NewMockDynamoDBAPI(ctrl)
+	api.EXPECT().Query(&dynamodb.QueryInput{
+		TableName: aws.String("valec"),
+		KeyConditions: map[string]*dynamodb.Condition{
+			"namespace": &dynamodb.Condition{
+				ComparisonOperator: aws.String(dynamodb.ComparisonOperatorEq),
+				AttributeValueList: []*dynamodb.AttributeValue{
+					&dynamodb.AttributeValue{
+						S: aws.String("test"),
+					},
+				},
+			},
+		},
+	}).Return(&dynamodb.QueryOutput{
+		Items: items,
+	}, nil)
+	api.EXPECT().BatchWriteItem(&dynamodb.BatchWriteItemInput{
+		RequestItems: map[string][]*dynamodb.WriteRequest{
+			"valec": trashRequests1,
+		},
+	}).Return(&dynamodb.BatchWriteItemOutput{}, nil)
+	api.EXPECT().BatchWriteItem(&dynamodb.BatchWriteItemInput{
+		RequestItems: map[string][]*dynamodb.WriteRequest{
+			"valec": trashRequests2,
+		},
+	}).Return(&dynamodb.BatchWriteItemOutput{}, nil)
+	api.EXPECT().BatchWriteItem(&dynamodb.BatchWriteItemInput{
+		RequestItems: map[string][]*dynamodb.WriteRequest{
+			"valec": writeRequests1,
+		},
+	}).Return(&dynamodb.BatchWriteItemOutput{}, nil)
+	api.EXPECT().BatchWriteItem(&dynamodb.BatchWriteItemInput{
+		RequestItems: map[string][]*dynamodb.WriteRequest{
+			"valec": writeRequests2,
+		},
+	}).Return(&dynamodb.BatchWriteItemOutput{}, nil)
+	api.EXPECT().DeleteItem(&dynamodb.DeleteItemInput{
+		TableName: aws.String("valec"),
+		Key: map[string]*dynamodb.AttributeValue{
+			"namespace": &dynamodb.AttributeValue{
+				S: aws.String(".namespaces"),
+			},
+			"key": &dynamodb.AttributeValue{
+				S: aws.String("test"),
+			},
+		},
+	}).Return(&dynamodb.DeleteItemOutput{}, nil)
+	client := &Client{
+		api: api,
+	}
+
+	table := "valec"
+	namespace := "test"
+	if err := client.DeleteNamespace(table, namespace); err != nil {
+		t.Errorf("Error should not be raised. error: %s", err)
+	}
+}
+
+func TestDeleteNamespace_empty(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := mock.NewMockDynamoDBAPI(ctrl)
+
+	// Namespace left in registry after its last secret was deleted
+	api.EXPECT().Query(&dynamodb.QueryInput{
+		TableName: aws.String("valec"),
+		KeyConditions: map[string]*dynamodb.Condition{
+			"namespace": &dynamodb.Condition{
+				ComparisonOperator: aws.String(dynamodb.ComparisonOperatorEq),
+				AttributeValueList: []*dynamodb.AttributeValue{
+					&dynamodb.AttributeValue{
+						S: aws.String("test"),
+					},
+				},
+			},
+		},
+	}).Return(&dynamodb.QueryOutput{}, nil)
+	api.EXPECT().BatchWriteItem(gomock.Any()).Times(0)
+	api.EXPECT().DeleteItem(&dynamodb.DeleteItemInput{
+		TableName: aws.String("valec"),
+		Key: map[string]*dynamodb.AttributeValue{
+			"namespace": &dynamodb.AttributeValue{
+				S: aws.String(".namespaces"),
+			},
+			"key": &dynamodb.AttributeValue{
+				S: aws.String("test"),
+			},
+		},
+	}).Return(&dynamodb.DeleteItemOutput{}, nil)
+	client := &Client{
+		api: api,
+	}
+
+	table := "valec"
+	namespace := "test"
+	if err := client.DeleteNamespace(table, namespace); err != nil {
+		t.Errorf("Error should not be raised. error: %s", err)
+	}
+}
+
+func TestDeleteNamespace_multiplePages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := mock.NewMockDynamoDBAPI(ctrl)
+
+	gomock.InOrder(
+		api.EXPECT().Query(&dynamodb.QueryInput{
+			TableName: aws.String("valec"),
+			KeyConditions: map[string]*dynamodb.Condition{
+				"namespace": &dynamodb.Condition{
+					ComparisonOperator: aws.String(dynamodb.ComparisonOperatorEq),
+					AttributeValueList: []*dynamodb.AttributeValue{
+						&dynamodb.AttributeValue{
+							S: aws.String("test"),
+						},
+					},
+				},
+			},
+		}).Return(&dynamodb.QueryOutput{
+			Items: []map[string]*dynamodb.AttributeValue{
+				map[string]*dynamodb.AttributeValue{
+					"namespace": &dynamodb.AttributeValue{
+						S: aws.String("test"),
+					},
+					"key": &dynamodb.AttributeValue{
+						S: aws.String("BAZ"),
+					},
+					"value": &dynamodb.AttributeValue{
+						S: aws.String("1"),
+					},
+				},
+				map[string]*dynamodb.AttributeValue{
+					"namespace": &dynamodb.AttributeValue{
+						S: aws.String("test"),
+					},
+					"key": &dynamodb.AttributeValue{
+						S: aws.String("FOO"),
+					},
+					"value": &dynamodb.AttributeValue{
+						S: aws.String("bar"),
+					},
+				},
+			},
+			LastEvaluatedKey: map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String("test"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("FOO"),
+				},
+			},
+		}, nil),
+		api.EXPECT().Query(&dynamodb.QueryInput{
+			TableName: aws.String("valec"),
+			KeyConditions: map[string]*dynamodb.Condition{
+				"namespace": &dynamodb.Condition{
+					ComparisonOperator: aws.String(dynamodb.ComparisonOperatorEq),
+					AttributeValueList: []*dynamodb.AttributeValue{
+						&dynamodb.AttributeValue{
+							S: aws.String("test"),
+						},
+					},
+				},
+			},
+			ExclusiveStartKey: map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String("test"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("FOO"),
+				},
+			},
+		}).Return(&dynamodb.QueryOutput{
+			Items: []map[string]*dynamodb.AttributeValue{
+				map[string]*dynamodb.AttributeValue{
+					"namespace": &dynamodb.AttributeValue{
+						S: aws.String("test"),
+					},
+					"key": &dynamodb.AttributeValue{
+						S: aws.String("QUX"),
+					},
+					"value": &dynamodb.AttributeValue{
+						S: aws.String("true"),
+					},
+				},
+			},
+		}, nil),
+	)
+	api.EXPECT().BatchWriteItem(&dynamodb.BatchWriteItemInput{
+		RequestItems: map[string][]*dynamodb.WriteRequest{
+			"valec": []*dynamodb.WriteRequest{
+				&dynamodb.WriteRequest{
+					PutRequest: &dynamodb.PutRequest{
+						Item: map[string]*dynamodb.AttributeValue{
+							"namespace": &dynamodb.AttributeValue{
+								S: aws.String(".trash"),
+							},
+							"key": &dynamodb.AttributeValue{
+								S: aws.String("test#BAZ#01483326245000000000"),
+							},
+							"value": &dynamodb.AttributeValue{
+								S: aws.String("1"),
+							},
+							"original_namespace": &dynamodb.AttributeValue{
+								S: aws.String("test"),
+							},
+							"original_key": &dynamodb.AttributeValue{
+								S: aws.String("BAZ"),
+							},
+							"deleted_at": &dynamodb.AttributeValue{
+								S: aws.String("2017-01-02T03:04:05Z"),
+							},
+							"expires_at": &dynamodb.AttributeValue{
+								N: aws.String("1485918245"),
+							},
+						},
+					},
+				},
+				&dynamodb.WriteRequest{
+					PutRequest: &dynamodb.PutRequest{
+						Item: map[string]*dynamodb.AttributeValue{
+							"namespace": &dynamodb.AttributeValue{
+								S: aws.String(".trash"),
+							},
+							"key": &dynamodb.AttributeValue{
+								S: aws.String("test#FOO#01483326245000000000"),
+							},
+							"value": &dynamodb.AttributeValue{
+								S: aws.String("bar"),
+							},
+							"original_namespace": &dynamodb.AttributeValue{
+								S: aws.String("test"),
+							},
+							"original_key": &dynamodb.AttributeValue{
+								S: aws.String("FOO"),
+							},
+							"deleted_at": &dynamodb.AttributeValue{
+								S: aws.String("2017-01-02T03:04:05Z"),
+							},
+							"expires_at": &dynamodb.AttributeValue{
+								N: aws.String("1485918245"),
+							},
+						},
+					},
+				},
+				&dynamodb.WriteRequest{
+					PutRequest: &dynamodb.PutRequest{
+						Item: map[string]*dynamodb.AttributeValue{
+							"namespace": &dynamodb.AttributeValue{
+								S: aws.String(".trash"),
+							},
+							"key": &dynamodb.AttributeValue{
+								S: aws.String("test#QUX#01483326245000000000"),
+							},
+							"value": &dynamodb.AttributeValue{
+								S: aws.String("true"),
+							},
+							"original_namespace": &dynamodb.AttributeValue{
+								S: aws.String("test"),
+							},
+							"original_key": &dynamodb.AttributeValue{
+								S: aws.String("QUX"),
+							},
+							"deleted_at": &dynamodb.AttributeValue{
+								S: aws.String("2017-01-02T03:04:05Z"),
+							},
+							"expires_at": &dynamodb.AttributeValue{
+								N: aws.String("1485918245"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}).Return(&dynamodb.BatchWriteItemOutput{}, nil)
+	api.EXPECT().BatchWriteItem(&dynamodb.BatchWriteItemInput{
+		RequestItems: map[string][]*dynamodb.WriteRequest{
+			"valec": []*dynamodb.WriteRequest{
+				&dynamodb.WriteRequest{
+					DeleteRequest: &dynamodb.DeleteRequest{
+						Key: map[string]*dynamodb.AttributeValue{
+							"namespace": &dynamodb.AttributeValue{
+								S: aws.String("test"),
+							},
+							"key": &dynamodb.AttributeValue{
+								S: aws.String("BAZ"),
+							},
+						},
+					},
+				},
+				&dynamodb.WriteRequest{
+					DeleteRequest: &dynamodb.DeleteRequest{
+						Key: map[string]*dynamodb.AttributeValue{
+							"namespace": &dynamodb.AttributeValue{
+								S: aws.String("test"),
+							},
+							"key": &dynamodb.AttributeValue{
+								S: aws.String("FOO"),
+							},
+						},
+					},
+				},
+				&dynamodb.WriteRequest{
+					DeleteRequest: &dynamodb.DeleteRequest{
+						Key: map[string]*dynamodb.AttributeValue{
+							"namespace": &dynamodb.AttributeValue{
+								S: aws.String("test"),
+							},
+							"key": &dynamodb.AttributeValue{
+								S: aws.String("QUX"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}).Return(&dynamodb.BatchWriteItemOutput{}, nil)
+	api.EXPECT().DeleteItem(&dynamodb.DeleteItemInput{
+		TableName: aws.String("valec"),
+		Key: map[string]*dynamodb.AttributeValue{
+			"namespace": &dynamodb.AttributeValue{
+				S: aws.String(".namespaces"),
+			},
+			"key": &dynamodb.AttributeValue{
+				S: aws.String("test"),
+			},
+		},
+	}).Return(&dynamodb.DeleteItemOutput{}, nil)
+	client := &Client{
+		api: api,
+	}
+
+	table := "valec"
+	namespace := "test"
+	if err := client.DeleteNamespace(table, namespace); err != nil {
+		t.Errorf("Error should not be raised. error: %s", err)
+	}
+}
+
+func TestDeleteNamespace_unprocessedItems(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sleep = func(d time.Duration) {}
+	defer func() {
+		sleep = time.Sleep
+	}()
+
+	api := mock.NewMockDynamoDBAPI(ctrl)
+
+	api.EXPECT().Query(&dynamodb.QueryInput{
+		TableName: aws.String("valec"),
+		KeyConditions: map[string]*dynamodb.Condition{
+			"namespace": &dynamodb.Condition{
+				ComparisonOperator: aws.String(dynamodb.ComparisonOperatorEq),
+				AttributeValueList: []*dynamodb.AttributeValue{
+					&dynamodb.AttributeValue{
+						S: aws.String("test"),
+					},
+				},
+			},
+		},
+	}).Return(&dynamodb.QueryOutput{
+		Items: []map[string]*dynamodb.AttributeValue{
+			map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String("test"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("BAZ"),
+				},
+				"value": &dynamodb.AttributeValue{
+					S: aws.String("1"),
+				},
+			},
+			map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String("test"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("FOO"),
+				},
+				"value": &dynamodb.AttributeValue{
+					S: aws.String("bar"),
+				},
+			},
+		},
+	}, nil)
+	api.EXPECT().BatchWriteItem(&dynamodb.BatchWriteItemInput{
+		RequestItems: map[string][]*dynamodb.WriteRequest{
+			"valec": []*dynamodb.WriteRequest{
+				&dynamodb.WriteRequest{
+					PutRequest: &dynamodb.PutRequest{
+						Item: map[string]*dynamodb.AttributeValue{
+							"namespace": &dynamodb.AttributeValue{
+								S: aws.String(".trash"),
+							},
+							"key": &dynamodb.AttributeValue{
+								S: aws.String("test#BAZ#01483326245000000000"),
+							},
+							"value": &dynamodb.AttributeValue{
+								S: aws.String("1"),
+							},
+							"original_namespace": &dynamodb.AttributeValue{
+								S: aws.String("test"),
+							},
+							"original_key": &dynamodb.AttributeValue{
+								S: aws.String("BAZ"),
+							},
+							"deleted_at": &dynamodb.AttributeValue{
+								S: aws.String("2017-01-02T03:04:05Z"),
+							},
+							"expires_at": &dynamodb.AttributeValue{
+								N: aws.String("1485918245"),
+							},
+						},
+					},
+				},
+				&dynamodb.WriteRequest{
+					PutRequest: &dynamodb.PutRequest{
+						Item: map[string]*dynamodb.AttributeValue{
+							"namespace": &dynamodb.AttributeValue{
+								S: aws.String(".trash"),
+							},
+							"key": &dynamodb.AttributeValue{
+								S: aws.String("test#FOO#01483326245000000000"),
+							},
+							"value": &dynamodb.AttributeValue{
+								S: aws.String("bar"),
+							},
+							"original_namespace": &dynamodb.AttributeValue{
+								S: aws.String("test"),
+							},
+							"original_key": &dynamodb.AttributeValue{
+								S: aws.String("FOO"),
+							},
+							"deleted_at": &dynamodb.AttributeValue{
+								S: aws.String("2017-01-02T03:04:05Z"),
+							},
+							"expires_at": &dynamodb.AttributeValue{
+								N: aws.String("1485918245"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}).Return(&dynamodb.BatchWriteItemOutput{}, nil)
+	api.EXPECT().BatchWriteItem(gomock.Any()).Return(&dynamodb.BatchWriteItemOutput{
+		UnprocessedItems: map[string][]*dynamodb.WriteRequest{
+			"valec": []*dynamodb.WriteRequest{
+				&dynamodb.WriteRequest{
+					DeleteRequest: &dynamodb.DeleteRequest{
+						Key: map[string]*dynamodb.AttributeValue{
+							"namespace": &dynamodb.AttributeValue{
+								S: aws.String("test"),
+							},
+							"key": &dynamodb.AttributeValue{
+								S: aws.String("BAZ"),
+							},
+						},
+					},
+				},
+				&dynamodb.WriteRequest{
+					DeleteRequest: &dynamodb.DeleteRequest{
+						Key: map[string]*dynamodb.AttributeValue{
+							"namespace": &dynamodb.AttributeValue{
+								S: aws.String("test"),
+							},
+							"key": &dynamodb.AttributeValue{
+								S: aws.String("FOO"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}, nil).Times(3)
+	client := &Client{
+		api:        api,
+		maxRetries: 2,
+	}
+
+	table := "valec"
+	namespace := "test"
+	err := client.DeleteNamespace(table, namespace)
+	if err == nil {
+		t.Fatalf("Error should be raised.")
+	}
+
+	expected := "Failed to delete items.: Failed to delete items.: Some items were not processed after 2 retries. keys=BAZ,FOO"
+	if err.Error() != expected {
+		t.Errorf("Error message does not match. expected: %q, actual: %q", expected, err.Error())
+	}
+}
+
+func TestGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := mock.NewMockDynamoDBAPI(ctrl)
+
+	api.EXPECT().Query(&dynamodb.QueryInput{
+		TableName: aws.String("valec"),
+		KeyConditions: map[string]*dynamodb.Condition{
+			"namespace": &dynamodb.Condition{
+				ComparisonOperator: aws.String(dynamodb.ComparisonOperatorEq),
+				AttributeValueList: []*dynamodb.AttributeValue{
+					&dynamodb.AttributeValue{
+						S: aws.String("test"),
+					},
+				},
+			},
+			"key": &dynamodb.Condition{
+				ComparisonOperator: aws.String(dynamodb.ComparisonOperatorEq),
+				AttributeValueList: []*dynamodb.AttributeValue{
+					&dynamodb.AttributeValue{
+						S: aws.String("FOO"),
+					},
+				},
+			},
+		},
+	}).Return(&dynamodb.QueryOutput{
+		Items: []map[string]*dynamodb.AttributeValue{
+			map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String("test"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("FOO"),
+				},
+				"value": &dynamodb.AttributeValue{
+					S: aws.String("bar"),
+				},
+			},
+		},
+	}, nil)
+	client := &Client{
+		api: api,
+	}
+
+	expected := &secret.Secret{
+		Key:   "FOO",
+		Value: "bar",
+	}
+
+	table := "valec"
+	namespace := "test"
+	key := "FOO"
+	actual, err := client.Get(table, namespace, key)
+	if err != nil {
+		t.Errorf("Error should not be raised. error: %s", err)
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Secret does not match. expected: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestGet_nosecret(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := mock.NewMockDynamoDBAPI(ctrl)
+
+	api.EXPECT().Query(&dynamodb.QueryInput{
+		TableName: aws.String("valec"),
+		KeyConditions: map[string]*dynamodb.Condition{
+			"namespace": &dynamodb.Condition{
+				ComparisonOperator: aws.String(dynamodb.ComparisonOperatorEq),
+				AttributeValueList: []*dynamodb.AttributeValue{
+					&dynamodb.AttributeValue{
+						S: aws.String("test"),
+					},
+				},
+			},
+			"key": &dynamodb.Condition{
+				ComparisonOperator: aws.String(dynamodb.ComparisonOperatorEq),
+				AttributeValueList: []*dynamodb.AttributeValue{
+					&dynamodb.AttributeValue{
+						S: aws.String("FOOBAR"),
+					},
+				},
+			},
+		},
+	}).Return(&dynamodb.QueryOutput{
+		Items: []map[string]*dynamodb.AttributeValue{},
+	}, nil)
+	client := &Client{
+		api: api,
+	}
+
+	table := "valec"
+	namespace := "test"
+	key := "FOOBAR"
+	_, err := client.Get(table, namespace, key)
+	if err == nil {
+		t.Errorf("Error should be raised.")
+	}
+}
+
+func TestHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := mock.NewMockDynamoDBAPI(ctrl)
+
+	api.EXPECT().Query(&dynamodb.QueryInput{
+		TableName: aws.String("valec"),
+		KeyConditions: map[string]*dynamodb.Condition{
+			"namespace": &dynamodb.Condition{
+				ComparisonOperator: aws.String(dynamodb.ComparisonOperatorEq),
+				AttributeValueList: []*dynamodb.AttributeValue{
+					&dynamodb.AttributeValue{
+						S: aws.String(".history/test"),
+					},
+				},
+			},
+			"key": &dynamodb.Condition{
+				ComparisonOperator: aws.String(dynamodb.ComparisonOperatorBeginsWith),
+				AttributeValueList: []*dynamodb.AttributeValue{
+					&dynamodb.AttributeValue{
+						S: aws.String("FOO#"),
+					},
+				},
+			},
+		},
+	}).Return(&dynamodb.QueryOutput{
+		Items: []map[string]*dynamodb.AttributeValue{
+			map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String(".history/test"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("FOO#01483326245000000000"),
+				},
+				"value": &dynamodb.AttributeValue{
+					S: aws.String("bar"),
+				},
+			},
+			map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String(".history/test"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("FOO#BAR#01483326245000000000"),
+				},
+				"value": &dynamodb.AttributeValue{
+					S: aws.String("foobar"),
+				},
+			},
+			map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String(".history/test"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("FOO#01483412645000000000"),
+				},
+				"value": &dynamodb.AttributeValue{
+					S: aws.String("baz"),
+				},
+				"version": &dynamodb.AttributeValue{
+					N: aws.String("2"),
+				},
+				"updated_at": &dynamodb.AttributeValue{
+					S: aws.String("2017-01-02T03:04:05Z"),
+				},
+			},
+		},
+	}, nil)
+	client := &Client{
+		api: api,
+	}
+
+	expected := []*secret.Secret{
+		&secret.Secret{
+			Key:       "FOO",
+			Value:     "bar",
+			HistoryID: "01483326245000000000",
+		},
+		&secret.Secret{
+			Key:       "FOO",
+			Value:     "baz",
+			Version:   2,
+			UpdatedAt: time.Date(2017, 1, 2, 3, 4, 5, 0, time.UTC),
+			HistoryID: "01483412645000000000",
+		},
+	}
+
+	actual, err := client.History("valec", "test", "FOO")
+	if err != nil {
+		t.Errorf("Error should not be raised. error: %s", err)
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Secrets do not match. expected: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestInsert(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := mock.NewMockDynamoDBAPI(ctrl)
+
+	gomock.InOrder(
+		api.EXPECT().PutItem(&dynamodb.PutItemInput{
+			TableName: aws.String("valec"),
+			Item: map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String("test"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("BAZ"),
+				},
+				"value": &dynamodb.AttributeValue{
+					S: aws.String("1"),
+				},
+				"version": &dynamodb.AttributeValue{
+					N: aws.String("1"),
+				},
+				"updated_at": &dynamodb.AttributeValue{
+					S: aws.String("2017-01-02T03:04:05Z"),
+				},
+			},
+			ConditionExpression: aws.String("attribute_not_exists(#version)"),
+			ExpressionAttributeNames: map[string]*string{
+				"#version": aws.String("version"),
+			},
+			ReturnValues: aws.String("ALL_OLD"),
+		}).Return(&dynamodb.PutItemOutput{}, nil),
+		api.EXPECT().PutItem(&dynamodb.PutItemInput{
+			TableName: aws.String("valec"),
+			Item: map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String("test"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("FOO"),
+				},
+				"value": &dynamodb.AttributeValue{
+					S: aws.String("bar"),
+				},
+				"version": &dynamodb.AttributeValue{
+					N: aws.String("3"),
+				},
+				"updated_at": &dynamodb.AttributeValue{
+					S: aws.String("2017-01-02T03:04:05Z"),
+				},
+			},
+			ConditionExpression: aws.String("#version = :version"),
+			ExpressionAttributeNames: map[string]*string{
+				"#version": aws.String("version"),
+			},
+			ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+				":version": &dynamodb.AttributeValue{
+					N: aws.String("2"),
+				},
+			},
+			ReturnValues: aws.String("ALL_OLD"),
+		}).Return(&dynamodb.PutItemOutput{}, nil),
+		api.EXPECT().PutItem(&dynamodb.PutItemInput{
+			TableName: aws.String("valec"),
+			Item: map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String("test"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("BAR"),
+				},
+				"value": &dynamodb.AttributeValue{
+					S: aws.String("fuga"),
+				},
+				"version": &dynamodb.AttributeValue{
+					N: aws.String("1"),
+				},
+				"updated_at": &dynamodb.AttributeValue{
+					S: aws.String("2017-01-02T03:04:05Z"),
+				},
+			},
+			ConditionExpression: aws.String("attribute_not_exists(#version)"),
+			ExpressionAttributeNames: map[string]*string{
+				"#version": aws.String("version"),
+			},
+			ReturnValues: aws.String("ALL_OLD"),
+		}).Return(&dynamodb.PutItemOutput{}, nil),
+		api.EXPECT().PutItem(&dynamodb.PutItemInput{
+			TableName: aws.String("valec"),
+			Item: map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String(".namespaces"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("test"),
+				},
+			},
+		}).Return(&dynamodb.PutItemOutput{}, nil),
+	)
+	client := &Client{
+		api: api,
+	}
+
+	secrets := []*secret.Secret{
+		&secret.Secret{
+			Key:   "BAZ",
+			Value: "1",
+		},
+		&secret.Secret{
+			Key:     "FOO",
+			Value:   "bar",
+			Version: 2,
+		},
+		&secret.Secret{
+			Key:   "BAR",
+			Value: "fuga",
+		},
+	}
+
+	table := "valec"
+	namespace := "test"
+	if err := client.Insert(table, namespace, secrets); err != nil {
+		t.Errorf("Error should not be raised. error: %s", err)
+	}
+}
+
+func TestInsert_conflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := mock.NewMockDynamoDBAPI(ctrl)
+
+	api.EXPECT().PutItem(&dynamodb.PutItemInput{
+		TableName: aws.String("valec"),
+		Item: map[string]*dynamodb.AttributeValue{
+			"namespace": &dynamodb.AttributeValue{
+				S: aws.String("test"),
+			},
+			"key": &dynamodb.AttributeValue{
+				S: aws.String("BAZ"),
+			},
+			"value": &dynamodb.AttributeValue{
+				S: aws.String("1"),
+			},
+			"version": &dynamodb.AttributeValue{
+				N: aws.String("1"),
+			},
+			"updated_at": &dynamodb.AttributeValue{
+				S: aws.String("2017-01-02T03:04:05Z"),
+			},
+		},
+		ConditionExpression: aws.String("attribute_not_exists(#version)"),
+		ExpressionAttributeNames: map[string]*string{
+			"#version": aws.String("version"),
+		},
+		ReturnValues: aws.String("ALL_OLD"),
+	}).Return(&dynamodb.PutItemOutput{}, nil)
+	api.EXPECT().PutItem(&dynamodb.PutItemInput{
+		TableName: aws.String("valec"),
+		Item: map[string]*dynamodb.AttributeValue{
+			"namespace": &dynamodb.AttributeValue{
+				S: aws.String("test"),
+			},
+			"key": &dynamodb.AttributeValue{
+				S: aws.String("FOO"),
+			},
+			"value": &dynamodb.AttributeValue{
+				S: aws.String("bar"),
+			},
+			"version": &dynamodb.AttributeValue{
+				N: aws.String("3"),
+			},
+			"updated_at": &dynamodb.AttributeValue{
+				S: aws.String("2017-01-02T03:04:05Z"),
+			},
+		},
+		ConditionExpression: aws.String("#version = :version"),
+		ExpressionAttributeNames: map[string]*string{
+			"#version": aws.String("version"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":version": &dynamodb.AttributeValue{
+				N: aws.String("2"),
+			},
+		},
+		ReturnValues: aws.String("ALL_OLD"),
+	}).Return(nil, awserr.New("ConditionalCheckFailedException", "The conditional request failed", nil))
+	api.EXPECT().PutItem(&dynamodb.PutItemInput{
+		TableName: aws.String("valec"),
+		Item: map[string]*dynamodb.AttributeValue{
+			"namespace": &dynamodb.AttributeValue{
+				S: aws.String(".namespaces"),
+			},
+			"key": &dynamodb.AttributeValue{
+				S: aws.String("test"),
+			},
+		},
+	}).Return(&dynamodb.PutItemOutput{}, nil)
+	client := &Client{
+		api: api,
+	}
+
+	secrets := []*secret.Secret{
+		&secret.Secret{
+			Key:   "BAZ",
+			Value: "1",
+		},
+		&secret.Secret{
+			Key:     "FOO",
+			Value:   "bar",
+			Version: 2,
+		},
+	}
+
+	table := "valec"
+	namespace := "test"
+	err := client.Insert(table, namespace, secrets)
+	if err == nil {
+		t.Fatalf("Error should be raised.")
+	}
+
+	conflict, ok := errors.Cause(err).(*secret.ConflictError)
+	if !ok {
+		t.Fatalf("ConflictError should be raised. error: %s", err)
+	}
+
+	expected := &secret.ConflictError{
+		Namespace: "test",
+		Keys:      []string{"FOO"},
+	}
+	if !reflect.DeepEqual(conflict, expected) {
+		t.Errorf("Conflict does not match. expected: %#v, actual: %#v", expected, conflict)
+	}
+}
+
+func TestInsert_dataKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := mock.NewMockDynamoDBAPI(ctrl)
+
+	api.EXPECT().PutItem(&dynamodb.PutItemInput{
+		TableName: aws.String("valec"),
+		Item: map[string]*dynamodb.AttributeValue{
+			"namespace": &dynamodb.AttributeValue{
+				S: aws.String("test"),
+			},
+			"key": &dynamodb.AttributeValue{
+				S: aws.String("FOO"),
+			},
+			"value": &dynamodb.AttributeValue{
+				S: aws.String("bar"),
+			},
+			"data_key": &dynamodb.AttributeValue{
+				S: aws.String("d3JhcHBlZA=="),
+			},
+			"version": &dynamodb.AttributeValue{
+				N: aws.String("1"),
+			},
+			"updated_at": &dynamodb.AttributeValue{
+				S: aws.String("2017-01-02T03:04:05Z"),
+			},
+		},
+		ConditionExpression: aws.String("attribute_not_exists(#version)"),
+		ExpressionAttributeNames: map[string]*string{
+			"#version": aws.String("version"),
+		},
+		ReturnValues: aws.String("ALL_OLD"),
+	}).Return(&dynamodb.PutItemOutput{}, nil)
+	api.EXPECT().PutItem(&dynamodb.PutItemInput{
+		TableName: aws.String("valec"),
+		Item: map[string]*dynamodb.AttributeValue{
+			"namespace": &dynamodb.AttributeValue{
+				S: aws.String(".namespaces"),
+			},
+			"key": &dynamodb.AttributeValue{
+				S: aws.String("test"),
+			},
+		},
+	}).Return(&dynamodb.PutItemOutput{}, nil)
+	client := &Client{
+		api: api,
+	}
+
+	secrets := []*secret.Secret{
+		&secret.Secret{
+			Key:     "FOO",
+			Value:   "bar",
+			DataKey: "d3JhcHBlZA==",
+		},
+	}
+
+	table := "valec"
+	namespace := "test"
+	if err := client.Insert(table, namespace, secrets); err != nil {
+		t.Errorf("Error should not be raised. error: %s", err)
+	}
+}
+
+func TestInsert_history(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := mock.NewMockDynamoDBAPI(ctrl)
+
+	gomock.InOrder(
+		api.EXPECT().PutItem(&dynamodb.PutItemInput{
+			TableName: aws.String("valec"),
+			Item: map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String("test"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("FOO"),
+				},
+				"value": &dynamodb.AttributeValue{
+					S: aws.String("baz"),
+				},
+				"version": &dynamodb.AttributeValue{
+					N: aws.String("3"),
+				},
+				"updated_at": &dynamodb.AttributeValue{
+					S: aws.String("2017-01-02T03:04:05Z"),
+				},
+			},
+			ConditionExpression: aws.String("#version = :version"),
+			ExpressionAttributeNames: map[string]*string{
+				"#version": aws.String("version"),
+			},
+			ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+				":version": &dynamodb.AttributeValue{
+					N: aws.String("2"),
+				},
+			},
+			ReturnValues: aws.String("ALL_OLD"),
+		}).Return(&dynamodb.PutItemOutput{
+			Attributes: map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String("test"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("FOO"),
+				},
+				"value": &dynamodb.AttributeValue{
+					S: aws.String("bar"),
+				},
+				"version": &dynamodb.AttributeValue{
+					N: aws.String("2"),
+				},
+				"updated_at": &dynamodb.AttributeValue{
+					S: aws.String("2017-01-01T00:00:00Z"),
+				},
+			},
+		}, nil),
+		api.EXPECT().PutItem(&dynamodb.PutItemInput{
+			TableName: aws.String("valec"),
+			Item: map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String(".history/test"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("FOO#01483326245000000000"),
+				},
+				"value": &dynamodb.AttributeValue{
+					S: aws.String("bar"),
+				},
+				"version": &dynamodb.AttributeValue{
+					N: aws.String("2"),
+				},
+				"updated_at": &dynamodb.AttributeValue{
+					S: aws.String("2017-01-01T00:00:00Z"),
+				},
+			},
+		}).Return(&dynamodb.PutItemOutput{}, nil),
+		api.EXPECT().PutItem(&dynamodb.PutItemInput{
+			TableName: aws.String("valec"),
+			Item: map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String(".namespaces"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("test"),
+				},
+			},
+		}).Return(&dynamodb.PutItemOutput{}, nil),
+	)
+	client := &Client{
+		api: api,
+	}
+
+	secrets := []*secret.Secret{
+		&secret.Secret{
+			Key:     "FOO",
+			Value:   "baz",
+			Version: 2,
+		},
+	}
+
+	table := "valec"
+	namespace := "test"
+	if err := client.Insert(table, namespace, secrets); err != nil {
+		t.Errorf("Error should not be raised. error: %s", err)
+	}
+}
+
+func TestInsert_metadata(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := mock.NewMockDynamoDBAPI(ctrl)
+
+	api.EXPECT().PutItem(&dynamodb.PutItemInput{
+		TableName: aws.String("valec"),
+		Item: map[string]*dynamodb.AttributeValue{
+			"namespace": &dynamodb.AttributeValue{
+				S: aws.String("test"),
+			},
+			"key": &dynamodb.AttributeValue{
+				S: aws.String("FOO"),
+			},
+			"value": &dynamodb.AttributeValue{
+				S: aws.String("bar"),
+			},
+			"updated_by": &dynamodb.AttributeValue{
+				S: aws.String("arn:aws:iam::123456789012:user/valec"),
+			},
+			"commit": &dynamodb.AttributeValue{
+				S: aws.String("0123456789abcdef0123456789abcdef01234567"),
+			},
+			"version": &dynamodb.AttributeValue{
+				N: aws.String("1"),
+			},
+			"updated_at": &dynamodb.AttributeValue{
+				S: aws.String("2017-01-02T03:04:05Z"),
+			},
+		},
+		ConditionExpression: aws.String("attribute_not_exists(#version)"),
+		ExpressionAttributeNames: map[string]*string{
+			"#version": aws.String("version"),
+		},
+		ReturnValues: aws.String("ALL_OLD"),
+	}).Return(&dynamodb.PutItemOutput{}, nil)
+	api.EXPECT().PutItem(&dynamodb.PutItemInput{
+		TableName: aws.String("valec"),
+		Item: map[string]*dynamodb.AttributeValue{
+			"namespace": &dynamodb.AttributeValue{
+				S: aws.String(".namespaces"),
+			},
+			"key": &dynamodb.AttributeValue{
+				S: aws.String("test"),
+			},
+		},
+	}).Return(&dynamodb.PutItemOutput{}, nil)
+	client := &Client{
+		api: api,
+	}
+
+	secrets := []*secret.Secret{
+		&secret.Secret{
+			Key:       "FOO",
+			Value:     "bar",
+			UpdatedBy: "arn:aws:iam::123456789012:user/valec",
+			Commit:    "0123456789abcdef0123456789abcdef01234567",
+		},
+	}
+
+	table := "valec"
+	namespace := "test"
+	if err := client.Insert(table, namespace, secrets); err != nil {
+		t.Errorf("Error should not be raised. error: %s", err)
+	}
+}
+
+func TestInsert_replicas(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := mock.NewMockDynamoDBAPI(ctrl)
+
+	api.EXPECT().PutItem(&dynamodb.PutItemInput{
+		TableName: aws.String("valec"),
+		Item: map[string]*dynamodb.AttributeValue{
+			"namespace": &dynamodb.AttributeValue{
+				S: aws.String("test"),
+			},
+			"key": &dynamodb.AttributeValue{
+				S: aws.String("FOO"),
+			},
+			"value": &dynamodb.AttributeValue{
+				S: aws.String("primary"),
+			},
+			"region": &dynamodb.AttributeValue{
+				S: aws.String("ap-northeast-1"),
+			},
+			"key_arn": &dynamodb.AttributeValue{
+				S: aws.String("arn:aws:kms:ap-northeast-1:123456789012:key/1"),
+			},
+			"replicas": &dynamodb.AttributeValue{
+				L: []*dynamodb.AttributeValue{
+					&dynamodb.AttributeValue{
+						M: map[string]*dynamodb.AttributeValue{
+							"region": &dynamodb.AttributeValue{
+								S: aws.String("us-west-2"),
+							},
+							"value": &dynamodb.AttributeValue{
+								S: aws.String("replica"),
+							},
+							"key_arn": &dynamodb.AttributeValue{
+								S: aws.String("arn:aws:kms:us-west-2:123456789012:key/2"),
+							},
+						},
+					},
+				},
+			},
+			"version": &dynamodb.AttributeValue{
+				N: aws.String("1"),
+			},
+			"updated_at": &dynamodb.AttributeValue{
+				S: aws.String("2017-01-02T03:04:05Z"),
+			},
+		},
+		ConditionExpression: aws.String("attribute_not_exists(#version)"),
+		ExpressionAttributeNames: map[string]*string{
+			"#version": aws.String("version"),
+		},
+		ReturnValues: aws.String("ALL_OLD"),
+	}).Return(&dynamodb.PutItemOutput{}, nil)
+	api.EXPECT().PutItem(&dynamodb.PutItemInput{
+		TableName: aws.String("valec"),
+		Item: map[string]*dynamodb.AttributeValue{
+			"namespace": &dynamodb.AttributeValue{
+				S: aws.String(".namespaces"),
+			},
+			"key": &dynamodb.AttributeValue{
+				S: aws.String("test"),
+			},
+		},
+	}).Return(&dynamodb.PutItemOutput{}, nil)
+	client := &Client{
+		api: api,
+	}
+
+	secrets := []*secret.Secret{
+		&secret.Secret{
+			Key:   "FOO",
+			Value: "primary",
+			Replicas: []*secret.Replica{
+				&secret.Replica{
+					Region: "us-west-2",
+					Value:  "replica",
+					KeyARN: "arn:aws:kms:us-west-2:123456789012:key/2",
+				},
+			},
+			Region: "ap-northeast-1",
+			KeyARN: "arn:aws:kms:ap-northeast-1:123456789012:key/1",
+		},
+	}
+
+	table := "valec"
+	namespace := "test"
+	if err := client.Insert(table, namespace, secrets); err != nil {
+		t.Errorf("Error should not be raised. error: %s", err)
+	}
+}
+
+func TestInsert_reservedNamespace(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := mock.NewMockDynamoDBAPI(ctrl)
+	client := &Client{
+		api: api,
+	}
+
+	secrets := []*secret.Secret{
+		&secret.Secret{
+			Key:   "FOO",
+			Value: "bar",
+		},
+	}
+
+	if err := client.Insert("valec", ".history/test", secrets); err == nil {
+		t.Errorf("Error should be raised.")
+	}
+}
+
+func TestListSecrets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := mock.NewMockDynamoDBAPI(ctrl)
+
+	api.EXPECT().Query(&dynamodb.QueryInput{
+		TableName: aws.String("valec"),
+		KeyConditions: map[string]*dynamodb.Condition{
+			"namespace": &dynamodb.Condition{
+				ComparisonOperator: aws.String(dynamodb.ComparisonOperatorEq),
+				AttributeValueList: []*dynamodb.AttributeValue{
+					&dynamodb.AttributeValue{
+						S: aws.String("test"),
+					},
+				},
+			},
+		},
+	}).Return(&dynamodb.QueryOutput{
+		Items: []map[string]*dynamodb.AttributeValue{
+			map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String("test"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("BAZ"),
+				},
+				"value": &dynamodb.AttributeValue{
+					S: aws.String("1"),
+				},
+			},
+			map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String("test"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("FOO"),
+				},
+				"value": &dynamodb.AttributeValue{
+					S: aws.String("bar"),
+				},
+			},
+			map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String("test"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("BAR"),
+				},
+				"value": &dynamodb.AttributeValue{
+					S: aws.String("fuga"),
+				},
+			},
+		},
+	}, nil)
+	client := &Client{
+		api: api,
+	}
+
+	expected := []*secret.Secret{
+		&secret.Secret{
+			Key:   "BAZ",
+			Value: "1",
+		},
+		&secret.Secret{
+			Key:   "FOO",
+			Value: "bar",
+		},
+		&secret.Secret{
+			Key:   "BAR",
+			Value: "fuga",
+		},
+	}
+
+	table := "valec"
+	namespace := "test"
+	actual, err := client.ListSecrets(table, namespace)
+	if err != nil {
+		t.Errorf("Error should not be raised. error: %s", err)
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Secrets does not match. expected: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestListSecrets_multiplePages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := mock.NewMockDynamoDBAPI(ctrl)
+
+	gomock.InOrder(
+		api.EXPECT().Query(&dynamodb.QueryInput{
+			TableName: aws.String("valec"),
+			KeyConditions: map[string]*dynamodb.Condition{
+				"namespace": &dynamodb.Condition{
+					ComparisonOperator: aws.String(dynamodb.ComparisonOperatorEq),
+					AttributeValueList: []*dynamodb.AttributeValue{
+						&dynamodb.AttributeValue{
+							S: aws.String("test"),
+						},
+					},
+				},
+			},
+		}).Return(&dynamodb.QueryOutput{
+			Items: []map[string]*dynamodb.AttributeValue{
+				map[string]*dynamodb.AttributeValue{
+					"namespace": &dynamodb.AttributeValue{
+						S: aws.String("test"),
+					},
+					"key": &dynamodb.AttributeValue{
+						S: aws.String("BAZ"),
+					},
+					"value": &dynamodb.AttributeValue{
+						S: aws.String("1"),
+					},
+				},
+				map[string]*dynamodb.AttributeValue{
+					"namespace": &dynamodb.AttributeValue{
+						S: aws.String("test"),
+					},
+					"key": &dynamodb.AttributeValue{
+						S: aws.String("FOO"),
+					},
+					"value": &dynamodb.AttributeValue{
+						S: aws.String("bar"),
+					},
+				},
+			},
+			LastEvaluatedKey: map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String("test"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("FOO"),
+				},
+			},
+		}, nil),
+		api.EXPECT().Query(&dynamodb.QueryInput{
+			TableName: aws.String("valec"),
+			KeyConditions: map[string]*dynamodb.Condition{
+				"namespace": &dynamodb.Condition{
+					ComparisonOperator: aws.String(dynamodb.ComparisonOperatorEq),
+					AttributeValueList: []*dynamodb.AttributeValue{
+						&dynamodb.AttributeValue{
+							S: aws.String("test"),
+						},
+					},
+				},
+			},
+			ExclusiveStartKey: map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String("test"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("FOO"),
+				},
+			},
+		}).Return(&dynamodb.QueryOutput{
+			Items: []map[string]*dynamodb.AttributeValue{
+				map[string]*dynamodb.AttributeValue{
+					"namespace": &dynamodb.AttributeValue{
+						S: aws.String("test"),
+					},
+					"key": &dynamodb.AttributeValue{
+						S: aws.String("QUX"),
+					},
+					"value": &dynamodb.AttributeValue{
+						S: aws.String("true"),
+					},
+				},
+			},
+		}, nil),
+	)
+	client := &Client{
+		api: api,
+	}
+
+	expected := []*secret.Secret{
+		&secret.Secret{
+			Key:   "BAZ",
+			Value: "1",
+		},
+		&secret.Secret{
+			Key:   "FOO",
+			Value: "bar",
+		},
+		&secret.Secret{
+			Key:   "QUX",
+			Value: "true",
+		},
+	}
+
+	table := "valec"
+	namespace := "test"
+	actual, err := client.ListSecrets(table, namespace)
+	if err != nil {
+		t.Errorf("Error should not be raised. error: %s", err)
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Secrets does not match. expected: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestListSecrets_dataKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := mock.NewMockDynamoDBAPI(ctrl)
+
+	api.EXPECT().Query(&dynamodb.QueryInput{
+		TableName: aws.String("valec"),
+		KeyConditions: map[string]*dynamodb.Condition{
+			"namespace": &dynamodb.Condition{
+				ComparisonOperator: aws.String(dynamodb.ComparisonOperatorEq),
+				AttributeValueList: []*dynamodb.AttributeValue{
+					&dynamodb.AttributeValue{
+						S: aws.String("test"),
+					},
+				},
+			},
+		},
+	}).Return(&dynamodb.QueryOutput{
+		Items: []map[string]*dynamodb.AttributeValue{
+			map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String("test"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("FOO"),
+				},
+				"value": &dynamodb.AttributeValue{
+					S: aws.String("envelope:bar"),
+				},
+				"data_key": &dynamodb.AttributeValue{
+					S: aws.String("d3JhcHBlZA=="),
+				},
+			},
+		},
+	}, nil)
+	client := &Client{
+		api: api,
+	}
+
+	expected := []*secret.Secret{
+		&secret.Secret{
+			Key:     "FOO",
+			Value:   "envelope:bar",
+			DataKey: "d3JhcHBlZA==",
+		},
+	}
+
+	table := "valec"
+	namespace := "test"
+	actual, err := client.ListSecrets(table, namespace)
+	if err != nil {
+		t.Errorf("Error should not be raised. error: %s", err)
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Secrets does not match. expected: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestListSecrets_metadata(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := mock.NewMockDynamoDBAPI(ctrl)
+
+	api.EXPECT().Query(&dynamodb.QueryInput{
+		TableName: aws.String("valec"),
+		KeyConditions: map[string]*dynamodb.Condition{
+			"namespace": &dynamodb.Condition{
+				ComparisonOperator: aws.String(dynamodb.ComparisonOperatorEq),
+				AttributeValueList: []*dynamodb.AttributeValue{
+					&dynamodb.AttributeValue{
+						S: aws.String("test"),
+					},
+				},
+			},
+		},
+	}).Return(&dynamodb.QueryOutput{
+		Items: []map[string]*dynamodb.AttributeValue{
+			map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String("test"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("FOO"),
+				},
+				"value": &dynamodb.AttributeValue{
+					S: aws.String("envelope:bar"),
+				},
+				"version": &dynamodb.AttributeValue{
+					N: aws.String("2"),
+				},
+				"updated_at": &dynamodb.AttributeValue{
+					S: aws.String("2017-01-02T03:04:05Z"),
+				},
+				"updated_by": &dynamodb.AttributeValue{
+					S: aws.String("arn:aws:iam::123456789012:user/valec"),
+				},
+				"commit": &dynamodb.AttributeValue{
+					S: aws.String("0123456789abcdef0123456789abcdef01234567"),
+				},
+			},
+		},
+	}, nil)
+	client := &Client{
+		api: api,
+	}
+
+	expected := []*secret.Secret{
+		&secret.Secret{
+			Key:       "FOO",
+			Value:     "envelope:bar",
+			Version:   2,
+			UpdatedAt: time.Date(2017, 1, 2, 3, 4, 5, 0, time.UTC),
+			UpdatedBy: "arn:aws:iam::123456789012:user/valec",
+			Commit:    "0123456789abcdef0123456789abcdef01234567",
+		},
+	}
+
+	table := "valec"
+	namespace := "test"
+	actual, err := client.ListSecrets(table, namespace)
+	if err != nil {
+		t.Errorf("Error should not be raised. error: %s", err)
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Secrets does not match. expected: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestListSecrets_replicas(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := mock.NewMockDynamoDBAPI(ctrl)
+
+	api.EXPECT().Query(&dynamodb.QueryInput{
+		TableName: aws.String("valec"),
+		KeyConditions: map[string]*dynamodb.Condition{
+			"namespace": &dynamodb.Condition{
+				ComparisonOperator: aws.String(dynamodb.ComparisonOperatorEq),
+				AttributeValueList: []*dynamodb.AttributeValue{
+					&dynamodb.AttributeValue{
+						S: aws.String("test"),
+					},
+				},
+			},
+		},
+	}).Return(&dynamodb.QueryOutput{
+		Items: []map[string]*dynamodb.AttributeValue{
+			map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String("test"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("FOO"),
+				},
+				"value": &dynamodb.AttributeValue{
+					S: aws.String("primary"),
+				},
+				"region": &dynamodb.AttributeValue{
+					S: aws.String("ap-northeast-1"),
+				},
+				"key_arn": &dynamodb.AttributeValue{
+					S: aws.String("arn:aws:kms:ap-northeast-1:123456789012:key/1"),
+				},
+				"replicas": &dynamodb.AttributeValue{
+					L: []*dynamodb.AttributeValue{
+						&dynamodb.AttributeValue{
+							M: map[string]*dynamodb.AttributeValue{
+								"region": &dynamodb.AttributeValue{
+									S: aws.String("us-west-2"),
+								},
+								"value": &dynamodb.AttributeValue{
+									S: aws.String("replica"),
+								},
+								"key_arn": &dynamodb.AttributeValue{
+									S: aws.String("arn:aws:kms:us-west-2:123456789012:key/2"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, nil)
+	client := &Client{
+		api: api,
+	}
+
+	expected := []*secret.Secret{
+		&secret.Secret{
+			Key:   "FOO",
+			Value: "primary",
+			Replicas: []*secret.Replica{
+				&secret.Replica{
+					Region: "us-west-2",
+					Value:  "replica",
+					KeyARN: "arn:aws:kms:us-west-2:123456789012:key/2",
+				},
+			},
+			Region: "ap-northeast-1",
+			KeyARN: "arn:aws:kms:ap-northeast-1:123456789012:key/1",
+		},
+	}
+
+	table := "valec"
+	namespace := "test"
+	actual, err := client.ListSecrets(table, namespace)
+	if err != nil {
 		t.Errorf("Error should not be raised. error: %s", err)
 	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Secrets does not match. expected: %v, actual: %v", expected, actual)
+	}
 }
 
-func TestDeleteNamespace(t *testing.T) {
+func TestListNamespaces(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -225,12 +2926,17 @@ func TestDeleteNamespace(t *testing.T) {
 				ComparisonOperator: aws.String(dynamodb.ComparisonOperatorEq),
 				AttributeValueList: []*dynamodb.AttributeValue{
 					&dynamodb.AttributeValue{
-						S: aws.String("test"),
+						S: aws.String(".namespaces"),
 					},
 				},
 			},
 		},
 	}).Return(&dynamodb.QueryOutput{
+		Items: []map[string]*dynamodb.AttributeValue{},
+	}, nil)
+	api.EXPECT().Scan(&dynamodb.ScanInput{
+		TableName: aws.String("valec"),
+	}).Return(&dynamodb.ScanOutput{
 		Items: []map[string]*dynamodb.AttributeValue{
 			map[string]*dynamodb.AttributeValue{
 				"namespace": &dynamodb.AttributeValue{
@@ -245,7 +2951,7 @@ func TestDeleteNamespace(t *testing.T) {
 			},
 			map[string]*dynamodb.AttributeValue{
 				"namespace": &dynamodb.AttributeValue{
-					S: aws.String("test"),
+					S: aws.String("test2"),
 				},
 				"key": &dynamodb.AttributeValue{
 					S: aws.String("FOO"),
@@ -265,116 +2971,173 @@ func TestDeleteNamespace(t *testing.T) {
 					S: aws.String("fuga"),
 				},
 			},
-		},
-	}, nil)
-	api.EXPECT().BatchWriteItem(&dynamodb.BatchWriteItemInput{
-		RequestItems: map[string][]*dynamodb.WriteRequest{
-			"valec": []*dynamodb.WriteRequest{
-				&dynamodb.WriteRequest{
-					DeleteRequest: &dynamodb.DeleteRequest{
-						Key: map[string]*dynamodb.AttributeValue{
-							"namespace": &dynamodb.AttributeValue{
-								S: aws.String("test"),
-							},
-							"key": &dynamodb.AttributeValue{
-								S: aws.String("BAZ"),
-							},
-						},
-					},
+			map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String("test3"),
 				},
-				&dynamodb.WriteRequest{
-					DeleteRequest: &dynamodb.DeleteRequest{
-						Key: map[string]*dynamodb.AttributeValue{
-							"namespace": &dynamodb.AttributeValue{
-								S: aws.String("test"),
-							},
-							"key": &dynamodb.AttributeValue{
-								S: aws.String("FOO"),
-							},
-						},
-					},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("FOO"),
 				},
-				&dynamodb.WriteRequest{
-					DeleteRequest: &dynamodb.DeleteRequest{
-						Key: map[string]*dynamodb.AttributeValue{
-							"namespace": &dynamodb.AttributeValue{
-								S: aws.String("test"),
-							},
-							"key": &dynamodb.AttributeValue{
-								S: aws.String("BAR"),
-							},
-						},
-					},
+				"value": &dynamodb.AttributeValue{
+					S: aws.String("fuga"),
+				},
+			},
+			map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String(".history/test4"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("FOO#00000000000000000001"),
+				},
+				"value": &dynamodb.AttributeValue{
+					S: aws.String("fuga"),
 				},
 			},
 		},
-	}).Return(&dynamodb.BatchWriteItemOutput{}, nil)
+	}, nil)
 	client := &Client{
 		api: api,
 	}
 
+	expected := []string{
+		"test",
+		"test2",
+		"test3",
+	}
+
 	table := "valec"
-	namespace := "test"
-	if err := client.DeleteNamespace(table, namespace); err != nil {
+	actual, err := client.ListNamespaces(table)
+	if err != nil {
 		t.Errorf("Error should not be raised. error: %s", err)
 	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Namespaces does not match. expected: %q, actual: %q", expected, actual)
+	}
 }
 
-func TestDeleteNamespace_30items(t *testing.T) {
+func TestListNamespaces_multiplePages(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	items := []map[string]*dynamodb.AttributeValue{}
+	api := mock.NewMockDynamoDBAPI(ctrl)
 
-	for i := 0; i < 30; i++ {
-		items = append(items, map[string]*dynamodb.AttributeValue{
-			"namespace": &dynamodb.AttributeValue{
-				S: aws.String("test"),
-			},
-			"key": &dynamodb.AttributeValue{
-				S: aws.String("BAZ" + strconv.Itoa(i)),
-			},
-			"value": &dynamodb.AttributeValue{
-				S: aws.String(strconv.Itoa(i)),
+	api.EXPECT().Query(&dynamodb.QueryInput{
+		TableName: aws.String("valec"),
+		KeyConditions: map[string]*dynamodb.Condition{
+			"namespace": &dynamodb.Condition{
+				ComparisonOperator: aws.String(dynamodb.ComparisonOperatorEq),
+				AttributeValueList: []*dynamodb.AttributeValue{
+					&dynamodb.AttributeValue{
+						S: aws.String(".namespaces"),
+					},
+				},
 			},
-		})
-	}
-
-	writeRequests1 := []*dynamodb.WriteRequest{}
-
-	for i := 0; i < 25; i++ {
-		writeRequests1 = append(writeRequests1, &dynamodb.WriteRequest{
-			DeleteRequest: &dynamodb.DeleteRequest{
-				Key: map[string]*dynamodb.AttributeValue{
+		},
+	}).Return(&dynamodb.QueryOutput{
+		Items: []map[string]*dynamodb.AttributeValue{},
+	}, nil)
+	gomock.InOrder(
+		api.EXPECT().Scan(&dynamodb.ScanInput{
+			TableName: aws.String("valec"),
+		}).Return(&dynamodb.ScanOutput{
+			Items: []map[string]*dynamodb.AttributeValue{
+				map[string]*dynamodb.AttributeValue{
 					"namespace": &dynamodb.AttributeValue{
 						S: aws.String("test"),
 					},
 					"key": &dynamodb.AttributeValue{
-						S: aws.String("BAZ" + strconv.Itoa(i)),
+						S: aws.String("BAZ"),
+					},
+					"value": &dynamodb.AttributeValue{
+						S: aws.String("1"),
+					},
+				},
+				map[string]*dynamodb.AttributeValue{
+					"namespace": &dynamodb.AttributeValue{
+						S: aws.String("test2"),
+					},
+					"key": &dynamodb.AttributeValue{
+						S: aws.String("FOO"),
+					},
+					"value": &dynamodb.AttributeValue{
+						S: aws.String("bar"),
 					},
 				},
 			},
-		})
-	}
-
-	writeRequests2 := []*dynamodb.WriteRequest{}
-
-	for i := 25; i < 30; i++ {
-		writeRequests2 = append(writeRequests2, &dynamodb.WriteRequest{
-			DeleteRequest: &dynamodb.DeleteRequest{
-				Key: map[string]*dynamodb.AttributeValue{
+			LastEvaluatedKey: map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String("test2"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("FOO"),
+				},
+			},
+		}, nil),
+		api.EXPECT().Scan(&dynamodb.ScanInput{
+			TableName: aws.String("valec"),
+			ExclusiveStartKey: map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String("test2"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("FOO"),
+				},
+			},
+		}).Return(&dynamodb.ScanOutput{
+			Items: []map[string]*dynamodb.AttributeValue{
+				map[string]*dynamodb.AttributeValue{
 					"namespace": &dynamodb.AttributeValue{
 						S: aws.String("test"),
 					},
 					"key": &dynamodb.AttributeValue{
-						S: aws.String("BAZ" + strconv.Itoa(i)),
+						S: aws.String("BAR"),
+					},
+					"value": &dynamodb.AttributeValue{
+						S: aws.String("fuga"),
+					},
+				},
+				map[string]*dynamodb.AttributeValue{
+					"namespace": &dynamodb.AttributeValue{
+						S: aws.String("test3"),
+					},
+					"key": &dynamodb.AttributeValue{
+						S: aws.String("FOO"),
+					},
+					"value": &dynamodb.AttributeValue{
+						S: aws.String("fuga"),
 					},
 				},
 			},
-		})
+		}, nil),
+	)
+	client := &Client{
+		api: api,
+	}
+
+	expected := []string{
+		"test",
+		"test2",
+		"test3",
+	}
+
+	table := "valec"
+	actual, err := client.ListNamespaces(table)
+	if err != nil {
+		t.Errorf("Error should not be raised. error: %s", err)
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Namespaces does not match. expected: %q, actual: %q", expected, actual)
 	}
+}
+
+func TestListNamespaces_notMigrated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
 
 	api := mock.NewMockDynamoDBAPI(ctrl)
+
 	api.EXPECT().Query(&dynamodb.QueryInput{
 		TableName: aws.String("valec"),
 		KeyConditions: map[string]*dynamodb.Condition{
@@ -382,36 +3145,81 @@ func TestDeleteNamespace_30items(t *testing.T) {
 				ComparisonOperator: aws.String(dynamodb.ComparisonOperatorEq),
 				AttributeValueList: []*dynamodb.AttributeValue{
 					&dynamodb.AttributeValue{
-						S: aws.String("test"),
+						S: aws.String(".namespaces"),
 					},
 				},
 			},
 		},
 	}).Return(&dynamodb.QueryOutput{
-		Items: items,
-	}, nil)
-	api.EXPECT().BatchWriteItem(&dynamodb.BatchWriteItemInput{
-		RequestItems: map[string][]*dynamodb.WriteRequest{
-			"valec": writeRequests1,
+		Items: []map[string]*dynamodb.AttributeValue{
+			map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String(".namespaces"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("test2"),
+				},
+			},
 		},
-	}).Return(&dynamodb.BatchWriteItemOutput{}, nil)
-	api.EXPECT().BatchWriteItem(&dynamodb.BatchWriteItemInput{
-		RequestItems: map[string][]*dynamodb.WriteRequest{
-			"valec": writeRequests2,
+	}, nil)
+	api.EXPECT().Scan(&dynamodb.ScanInput{
+		TableName: aws.String("valec"),
+	}).Return(&dynamodb.ScanOutput{
+		Items: []map[string]*dynamodb.AttributeValue{
+			map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String(".namespaces"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("test2"),
+				},
+			},
+			map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String("test"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("FOO"),
+				},
+				"value": &dynamodb.AttributeValue{
+					S: aws.String("bar"),
+				},
+			},
+			map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String("test2"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("BAZ"),
+				},
+				"value": &dynamodb.AttributeValue{
+					S: aws.String("1"),
+				},
+			},
 		},
-	}).Return(&dynamodb.BatchWriteItemOutput{}, nil)
+	}, nil)
 	client := &Client{
 		api: api,
 	}
 
+	// Namespaces written by older valec are not registered
+	expected := []string{
+		"test",
+		"test2",
+	}
+
 	table := "valec"
-	namespace := "test"
-	if err := client.DeleteNamespace(table, namespace); err != nil {
+	actual, err := client.ListNamespaces(table)
+	if err != nil {
 		t.Errorf("Error should not be raised. error: %s", err)
 	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Namespaces does not match. expected: %q, actual: %q", expected, actual)
+	}
 }
 
-func TestGet(t *testing.T) {
+func TestListNamespaces_registry(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -424,15 +3232,7 @@ func TestGet(t *testing.T) {
 				ComparisonOperator: aws.String(dynamodb.ComparisonOperatorEq),
 				AttributeValueList: []*dynamodb.AttributeValue{
 					&dynamodb.AttributeValue{
-						S: aws.String("test"),
-					},
-				},
-			},
-			"key": &dynamodb.Condition{
-				ComparisonOperator: aws.String(dynamodb.ComparisonOperatorEq),
-				AttributeValueList: []*dynamodb.AttributeValue{
-					&dynamodb.AttributeValue{
-						S: aws.String("FOO"),
+						S: aws.String(".namespaces"),
 					},
 				},
 			},
@@ -441,13 +3241,34 @@ func TestGet(t *testing.T) {
 		Items: []map[string]*dynamodb.AttributeValue{
 			map[string]*dynamodb.AttributeValue{
 				"namespace": &dynamodb.AttributeValue{
+					S: aws.String(".namespaces"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String(".migrated"),
+				},
+			},
+			map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String(".namespaces"),
+				},
+				"key": &dynamodb.AttributeValue{
 					S: aws.String("test"),
 				},
+			},
+			map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String(".namespaces"),
+				},
 				"key": &dynamodb.AttributeValue{
-					S: aws.String("FOO"),
+					S: aws.String("test2"),
 				},
-				"value": &dynamodb.AttributeValue{
-					S: aws.String("bar"),
+			},
+			map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String(".namespaces"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("test3"),
 				},
 			},
 		},
@@ -456,25 +3277,24 @@ func TestGet(t *testing.T) {
 		api: api,
 	}
 
-	expected := &secret.Secret{
-		Key:   "FOO",
-		Value: "bar",
+	expected := []string{
+		"test",
+		"test2",
+		"test3",
 	}
 
 	table := "valec"
-	namespace := "test"
-	key := "FOO"
-	actual, err := client.Get(table, namespace, key)
+	actual, err := client.ListNamespaces(table)
 	if err != nil {
 		t.Errorf("Error should not be raised. error: %s", err)
 	}
 
 	if !reflect.DeepEqual(actual, expected) {
-		t.Errorf("Secret does not match. expected: %v, actual: %v", expected, actual)
+		t.Errorf("Namespaces does not match. expected: %q, actual: %q", expected, actual)
 	}
 }
 
-func TestGet_nosecret(t *testing.T) {
+func TestListTrash(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -487,194 +3307,96 @@ func TestGet_nosecret(t *testing.T) {
 				ComparisonOperator: aws.String(dynamodb.ComparisonOperatorEq),
 				AttributeValueList: []*dynamodb.AttributeValue{
 					&dynamodb.AttributeValue{
-						S: aws.String("test"),
-					},
-				},
-			},
-			"key": &dynamodb.Condition{
-				ComparisonOperator: aws.String(dynamodb.ComparisonOperatorEq),
-				AttributeValueList: []*dynamodb.AttributeValue{
-					&dynamodb.AttributeValue{
-						S: aws.String("FOOBAR"),
+						S: aws.String(".trash"),
 					},
 				},
 			},
 		},
 	}).Return(&dynamodb.QueryOutput{
-		Items: []map[string]*dynamodb.AttributeValue{},
-	}, nil)
-	client := &Client{
-		api: api,
-	}
-
-	table := "valec"
-	namespace := "test"
-	key := "FOOBAR"
-	_, err := client.Get(table, namespace, key)
-	if err == nil {
-		t.Errorf("Error should be raised.")
-	}
-}
-
-func TestInsert(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-
-	api := mock.NewMockDynamoDBAPI(ctrl)
-
-	api.EXPECT().BatchWriteItem(&dynamodb.BatchWriteItemInput{
-		RequestItems: map[string][]*dynamodb.WriteRequest{
-			"valec": []*dynamodb.WriteRequest{
-				&dynamodb.WriteRequest{
-					PutRequest: &dynamodb.PutRequest{
-						Item: map[string]*dynamodb.AttributeValue{
-							"namespace": &dynamodb.AttributeValue{
-								S: aws.String("test"),
-							},
-							"key": &dynamodb.AttributeValue{
-								S: aws.String("BAZ"),
-							},
-							"value": &dynamodb.AttributeValue{
-								S: aws.String("1"),
-							},
-						},
-					},
+		Items: []map[string]*dynamodb.AttributeValue{
+			map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String(".trash"),
 				},
-				&dynamodb.WriteRequest{
-					PutRequest: &dynamodb.PutRequest{
-						Item: map[string]*dynamodb.AttributeValue{
-							"namespace": &dynamodb.AttributeValue{
-								S: aws.String("test"),
-							},
-							"key": &dynamodb.AttributeValue{
-								S: aws.String("FOO"),
-							},
-							"value": &dynamodb.AttributeValue{
-								S: aws.String("bar"),
-							},
-						},
-					},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("test#FOO#01483326245000000000"),
 				},
-				&dynamodb.WriteRequest{
-					PutRequest: &dynamodb.PutRequest{
-						Item: map[string]*dynamodb.AttributeValue{
-							"namespace": &dynamodb.AttributeValue{
-								S: aws.String("test"),
-							},
-							"key": &dynamodb.AttributeValue{
-								S: aws.String("BAR"),
-							},
-							"value": &dynamodb.AttributeValue{
-								S: aws.String("fuga"),
-							},
-						},
-					},
+				"value": &dynamodb.AttributeValue{
+					S: aws.String("bar"),
 				},
-			},
-		},
-	}).Return(&dynamodb.BatchWriteItemOutput{}, nil)
-	client := &Client{
-		api: api,
-	}
-
-	secrets := []*secret.Secret{
-		&secret.Secret{
-			Key:   "BAZ",
-			Value: "1",
-		},
-		&secret.Secret{
-			Key:   "FOO",
-			Value: "bar",
-		},
-		&secret.Secret{
-			Key:   "BAR",
-			Value: "fuga",
-		},
-	}
-
-	table := "valec"
-	namespace := "test"
-	if err := client.Insert(table, namespace, secrets); err != nil {
-		t.Errorf("Error should not be raised. error: %s", err)
-	}
-}
-
-func TestInsert_30items(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-
-	writeRequests1 := []*dynamodb.WriteRequest{}
-
-	for i := 0; i < 25; i++ {
-		writeRequests1 = append(writeRequests1, &dynamodb.WriteRequest{
-			PutRequest: &dynamodb.PutRequest{
-				Item: map[string]*dynamodb.AttributeValue{
-					"namespace": &dynamodb.AttributeValue{
-						S: aws.String("test"),
-					},
-					"key": &dynamodb.AttributeValue{
-						S: aws.String("BAZ" + strconv.Itoa(i)),
-					},
-					"value": &dynamodb.AttributeValue{
-						S: aws.String(strconv.Itoa(i)),
-					},
+				"version": &dynamodb.AttributeValue{
+					N: aws.String("2"),
+				},
+				"original_namespace": &dynamodb.AttributeValue{
+					S: aws.String("test"),
+				},
+				"original_key": &dynamodb.AttributeValue{
+					S: aws.String("FOO"),
+				},
+				"deleted_at": &dynamodb.AttributeValue{
+					S: aws.String("2017-01-02T03:04:05Z"),
+				},
+				"expires_at": &dynamodb.AttributeValue{
+					N: aws.String("1485918245"),
 				},
 			},
-		})
-	}
-
-	writeRequests2 := []*dynamodb.WriteRequest{}
-
-	for i := 25; i < 30; i++ {
-		writeRequests2 = append(writeRequests2, &dynamodb.WriteRequest{
-			PutRequest: &dynamodb.PutRequest{
-				Item: map[string]*dynamodb.AttributeValue{
-					"namespace": &dynamodb.AttributeValue{
-						S: aws.String("test"),
-					},
-					"key": &dynamodb.AttributeValue{
-						S: aws.String("BAZ" + strconv.Itoa(i)),
-					},
-					"value": &dynamodb.AttributeValue{
-						S: aws.String(strconv.Itoa(i)),
-					},
+			map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String(".trash"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("test2#BAZ#01483326245000000000"),
+				},
+				"value": &dynamodb.AttributeValue{
+					S: aws.String("qux"),
+				},
+				"version": &dynamodb.AttributeValue{
+					N: aws.String("1"),
+				},
+				"original_namespace": &dynamodb.AttributeValue{
+					S: aws.String("test2"),
+				},
+				"original_key": &dynamodb.AttributeValue{
+					S: aws.String("BAZ"),
+				},
+				"deleted_at": &dynamodb.AttributeValue{
+					S: aws.String("2017-01-02T03:04:05Z"),
+				},
+				"expires_at": &dynamodb.AttributeValue{
+					N: aws.String("1483326245"),
 				},
 			},
-		})
-	}
-
-	api := mock.NewMockDynamoDBAPI(ctrl)
-	api.EXPECT().BatchWriteItem(&dynamodb.BatchWriteItemInput{
-		RequestItems: map[string][]*dynamodb.WriteRequest{
-			"valec": writeRequests1,
-		},
-	}).Return(&dynamodb.BatchWriteItemOutput{}, nil)
-	api.EXPECT().BatchWriteItem(&dynamodb.BatchWriteItemInput{
-		RequestItems: map[string][]*dynamodb.WriteRequest{
-			"valec": writeRequests2,
 		},
-	}).Return(&dynamodb.BatchWriteItemOutput{}, nil)
+	}, nil)
 	client := &Client{
 		api: api,
 	}
 
-	secrets := []*secret.Secret{}
+	expected := []*secret.Trashed{
+		&secret.Trashed{
+			ID:        "test#FOO#01483326245000000000",
+			Namespace: "test",
+			Secret: &secret.Secret{
+				Key:     "FOO",
+				Value:   "bar",
+				Version: 2,
+			},
+			DeletedAt: time.Date(2017, 1, 2, 3, 4, 5, 0, time.UTC),
+			ExpiresAt: time.Unix(1485918245, 0),
+		},
+	}
 
-	for i := 0; i < 30; i++ {
-		secrets = append(secrets, &secret.Secret{
-			Key:   "BAZ" + strconv.Itoa(i),
-			Value: strconv.Itoa(i),
-		})
+	actual, err := client.ListTrash("valec", "")
+	if err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
 	}
 
-	table := "valec"
-	namespace := "test"
-	if err := client.Insert(table, namespace, secrets); err != nil {
-		t.Errorf("Error should not be raised. error: %s", err)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Trash does not match. expected: %#v, actual: %#v", expected, actual)
 	}
 }
 
-func TestListSecrets(t *testing.T) {
+func TestListTrash_namespace(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -687,7 +3409,15 @@ func TestListSecrets(t *testing.T) {
 				ComparisonOperator: aws.String(dynamodb.ComparisonOperatorEq),
 				AttributeValueList: []*dynamodb.AttributeValue{
 					&dynamodb.AttributeValue{
-						S: aws.String("test"),
+						S: aws.String(".trash"),
+					},
+				},
+			},
+			"key": &dynamodb.Condition{
+				ComparisonOperator: aws.String(dynamodb.ComparisonOperatorBeginsWith),
+				AttributeValueList: []*dynamodb.AttributeValue{
+					&dynamodb.AttributeValue{
+						S: aws.String("test#"),
 					},
 				},
 			},
@@ -696,35 +3426,54 @@ func TestListSecrets(t *testing.T) {
 		Items: []map[string]*dynamodb.AttributeValue{
 			map[string]*dynamodb.AttributeValue{
 				"namespace": &dynamodb.AttributeValue{
-					S: aws.String("test"),
+					S: aws.String(".trash"),
 				},
 				"key": &dynamodb.AttributeValue{
-					S: aws.String("BAZ"),
+					S: aws.String("test#FOO#01483326245000000000"),
 				},
 				"value": &dynamodb.AttributeValue{
-					S: aws.String("1"),
+					S: aws.String("bar"),
 				},
-			},
-			map[string]*dynamodb.AttributeValue{
-				"namespace": &dynamodb.AttributeValue{
+				"version": &dynamodb.AttributeValue{
+					N: aws.String("2"),
+				},
+				"original_namespace": &dynamodb.AttributeValue{
 					S: aws.String("test"),
 				},
-				"key": &dynamodb.AttributeValue{
+				"original_key": &dynamodb.AttributeValue{
 					S: aws.String("FOO"),
 				},
-				"value": &dynamodb.AttributeValue{
-					S: aws.String("bar"),
+				"deleted_at": &dynamodb.AttributeValue{
+					S: aws.String("2017-01-02T03:04:05Z"),
+				},
+				"expires_at": &dynamodb.AttributeValue{
+					N: aws.String("1485918245"),
 				},
 			},
 			map[string]*dynamodb.AttributeValue{
 				"namespace": &dynamodb.AttributeValue{
-					S: aws.String("test"),
+					S: aws.String(".trash"),
 				},
 				"key": &dynamodb.AttributeValue{
-					S: aws.String("BAR"),
+					S: aws.String("test#2#BAZ#01483326245000000000"),
 				},
 				"value": &dynamodb.AttributeValue{
-					S: aws.String("fuga"),
+					S: aws.String("qux"),
+				},
+				"version": &dynamodb.AttributeValue{
+					N: aws.String("1"),
+				},
+				"original_namespace": &dynamodb.AttributeValue{
+					S: aws.String("test#2"),
+				},
+				"original_key": &dynamodb.AttributeValue{
+					S: aws.String("BAZ"),
+				},
+				"deleted_at": &dynamodb.AttributeValue{
+					S: aws.String("2017-01-02T03:04:05Z"),
+				},
+				"expires_at": &dynamodb.AttributeValue{
+					N: aws.String("1485918245"),
 				},
 			},
 		},
@@ -733,34 +3482,21 @@ func TestListSecrets(t *testing.T) {
 		api: api,
 	}
 
-	expected := []*secret.Secret{
-		&secret.Secret{
-			Key:   "BAZ",
-			Value: "1",
-		},
-		&secret.Secret{
-			Key:   "FOO",
-			Value: "bar",
-		},
-		&secret.Secret{
-			Key:   "BAR",
-			Value: "fuga",
-		},
+	actual, err := client.ListTrash("valec", "test")
+	if err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
 	}
 
-	table := "valec"
-	namespace := "test"
-	actual, err := client.ListSecrets(table, namespace)
-	if err != nil {
-		t.Errorf("Error should not be raised. error: %s", err)
+	if len(actual) != 1 {
+		t.Fatalf("Number of deleted secrets does not match. expected: 1, actual: %d", len(actual))
 	}
 
-	if !reflect.DeepEqual(actual, expected) {
-		t.Errorf("Secrets does not match. expected: %v, actual: %v", expected, actual)
+	if actual[0].Namespace != "test" || actual[0].Secret.Key != "FOO" {
+		t.Errorf("Deleted secret does not match. expected: test FOO, actual: %s %s", actual[0].Namespace, actual[0].Secret.Key)
 	}
 }
 
-func TestListNamespaces(t *testing.T) {
+func TestMigrateNamespaceRegistry(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -770,17 +3506,6 @@ func TestListNamespaces(t *testing.T) {
 		TableName: aws.String("valec"),
 	}).Return(&dynamodb.ScanOutput{
 		Items: []map[string]*dynamodb.AttributeValue{
-			map[string]*dynamodb.AttributeValue{
-				"namespace": &dynamodb.AttributeValue{
-					S: aws.String("test"),
-				},
-				"key": &dynamodb.AttributeValue{
-					S: aws.String("BAZ"),
-				},
-				"value": &dynamodb.AttributeValue{
-					S: aws.String("1"),
-				},
-			},
 			map[string]*dynamodb.AttributeValue{
 				"namespace": &dynamodb.AttributeValue{
 					S: aws.String("test2"),
@@ -794,28 +3519,66 @@ func TestListNamespaces(t *testing.T) {
 			},
 			map[string]*dynamodb.AttributeValue{
 				"namespace": &dynamodb.AttributeValue{
-					S: aws.String("test"),
+					S: aws.String(".namespaces"),
 				},
 				"key": &dynamodb.AttributeValue{
-					S: aws.String("BAR"),
-				},
-				"value": &dynamodb.AttributeValue{
-					S: aws.String("fuga"),
+					S: aws.String("test3"),
 				},
 			},
 			map[string]*dynamodb.AttributeValue{
 				"namespace": &dynamodb.AttributeValue{
-					S: aws.String("test3"),
+					S: aws.String("test"),
 				},
 				"key": &dynamodb.AttributeValue{
-					S: aws.String("FOO"),
+					S: aws.String("BAZ"),
 				},
 				"value": &dynamodb.AttributeValue{
-					S: aws.String("fuga"),
+					S: aws.String("1"),
 				},
 			},
 		},
 	}, nil)
+	api.EXPECT().BatchWriteItem(&dynamodb.BatchWriteItemInput{
+		RequestItems: map[string][]*dynamodb.WriteRequest{
+			"valec": []*dynamodb.WriteRequest{
+				&dynamodb.WriteRequest{
+					PutRequest: &dynamodb.PutRequest{
+						Item: map[string]*dynamodb.AttributeValue{
+							"namespace": &dynamodb.AttributeValue{
+								S: aws.String(".namespaces"),
+							},
+							"key": &dynamodb.AttributeValue{
+								S: aws.String("test"),
+							},
+						},
+					},
+				},
+				&dynamodb.WriteRequest{
+					PutRequest: &dynamodb.PutRequest{
+						Item: map[string]*dynamodb.AttributeValue{
+							"namespace": &dynamodb.AttributeValue{
+								S: aws.String(".namespaces"),
+							},
+							"key": &dynamodb.AttributeValue{
+								S: aws.String("test2"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}).Return(&dynamodb.BatchWriteItemOutput{}, nil)
+	api.EXPECT().PutItem(&dynamodb.PutItemInput{
+		TableName: aws.String("valec"),
+		Item: map[string]*dynamodb.AttributeValue{
+			"namespace": &dynamodb.AttributeValue{
+				S: aws.String(".namespaces"),
+			},
+			"key": &dynamodb.AttributeValue{
+				S: aws.String(".migrated"),
+			},
+		},
+	}).Return(&dynamodb.PutItemOutput{}, nil)
 	client := &Client{
 		api: api,
 	}
@@ -823,11 +3586,10 @@ func TestListNamespaces(t *testing.T) {
 	expected := []string{
 		"test",
 		"test2",
-		"test3",
 	}
 
 	table := "valec"
-	actual, err := client.ListNamespaces(table)
+	actual, err := client.MigrateNamespaceRegistry(table)
 	if err != nil {
 		t.Errorf("Error should not be raised. error: %s", err)
 	}
@@ -847,6 +3609,37 @@ func TestNamespaceExists(t *testing.T) {
 		api: api,
 	}
 
+	api.EXPECT().GetItem(&dynamodb.GetItemInput{
+		TableName: aws.String("valec"),
+		Key: map[string]*dynamodb.AttributeValue{
+			"namespace": &dynamodb.AttributeValue{
+				S: aws.String(".namespaces"),
+			},
+			"key": &dynamodb.AttributeValue{
+				S: aws.String("test4"),
+			},
+		},
+	}).Return(&dynamodb.GetItemOutput{
+		Item: map[string]*dynamodb.AttributeValue{
+			"namespace": &dynamodb.AttributeValue{
+				S: aws.String(".namespaces"),
+			},
+			"key": &dynamodb.AttributeValue{
+				S: aws.String("test4"),
+			},
+		},
+	}, nil)
+	api.EXPECT().GetItem(&dynamodb.GetItemInput{
+		TableName: aws.String("valec"),
+		Key: map[string]*dynamodb.AttributeValue{
+			"namespace": &dynamodb.AttributeValue{
+				S: aws.String(".namespaces"),
+			},
+			"key": &dynamodb.AttributeValue{
+				S: aws.String("test"),
+			},
+		},
+	}).Return(&dynamodb.GetItemOutput{}, nil)
 	api.EXPECT().Query(&dynamodb.QueryInput{
 		TableName: aws.String("valec"),
 		KeyConditions: map[string]*dynamodb.Condition{
@@ -896,6 +3689,17 @@ func TestNamespaceExists(t *testing.T) {
 			},
 		},
 	}, nil)
+	api.EXPECT().GetItem(&dynamodb.GetItemInput{
+		TableName: aws.String("valec"),
+		Key: map[string]*dynamodb.AttributeValue{
+			"namespace": &dynamodb.AttributeValue{
+				S: aws.String(".namespaces"),
+			},
+			"key": &dynamodb.AttributeValue{
+				S: aws.String("foobar"),
+			},
+		},
+	}).Return(&dynamodb.GetItemOutput{}, nil)
 	api.EXPECT().Query(&dynamodb.QueryInput{
 		TableName: aws.String("valec"),
 		KeyConditions: map[string]*dynamodb.Condition{
@@ -917,6 +3721,10 @@ func TestNamespaceExists(t *testing.T) {
 		namespace string
 		expected  bool
 	}{
+		{
+			namespace: "test4",
+			expected:  true,
+		},
 		{
 			namespace: "test",
 			expected:  true,
@@ -939,6 +3747,218 @@ func TestNamespaceExists(t *testing.T) {
 	}
 }
 
+func TestRestore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := mock.NewMockDynamoDBAPI(ctrl)
+
+	gomock.InOrder(
+		api.EXPECT().PutItem(&dynamodb.PutItemInput{
+			TableName: aws.String("valec"),
+			Item: map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String("test"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("FOO"),
+				},
+				"value": &dynamodb.AttributeValue{
+					S: aws.String("bar"),
+				},
+				"version": &dynamodb.AttributeValue{
+					N: aws.String("3"),
+				},
+				"updated_at": &dynamodb.AttributeValue{
+					S: aws.String("2017-01-02T03:04:05Z"),
+				},
+			},
+			ConditionExpression: aws.String("attribute_not_exists(#key)"),
+			ExpressionAttributeNames: map[string]*string{
+				"#key": aws.String("key"),
+			},
+			ReturnValues: aws.String("ALL_OLD"),
+		}).Return(&dynamodb.PutItemOutput{}, nil),
+		api.EXPECT().DeleteItem(&dynamodb.DeleteItemInput{
+			TableName: aws.String("valec"),
+			Key: map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String(".trash"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("test#FOO#01483326245000000000"),
+				},
+			},
+		}).Return(&dynamodb.DeleteItemOutput{}, nil),
+		api.EXPECT().PutItem(&dynamodb.PutItemInput{
+			TableName: aws.String("valec"),
+			Item: map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String(".namespaces"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("test"),
+				},
+			},
+		}).Return(&dynamodb.PutItemOutput{}, nil),
+	)
+	client := &Client{
+		api: api,
+	}
+
+	trashed := []*secret.Trashed{
+		&secret.Trashed{
+			ID:        "test#FOO#01483326245000000000",
+			Namespace: "test",
+			Secret: &secret.Secret{
+				Key:     "FOO",
+				Value:   "bar",
+				Version: 2,
+			},
+		},
+	}
+
+	if err := client.Restore("valec", "test", trashed); err != nil {
+		t.Errorf("Error should not be raised. error: %s", err)
+	}
+}
+
+func TestRestore_conflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := mock.NewMockDynamoDBAPI(ctrl)
+
+	gomock.InOrder(
+		api.EXPECT().PutItem(&dynamodb.PutItemInput{
+			TableName: aws.String("valec"),
+			Item: map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String("test"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("FOO"),
+				},
+				"value": &dynamodb.AttributeValue{
+					S: aws.String("bar"),
+				},
+				"version": &dynamodb.AttributeValue{
+					N: aws.String("3"),
+				},
+				"updated_at": &dynamodb.AttributeValue{
+					S: aws.String("2017-01-02T03:04:05Z"),
+				},
+			},
+			ConditionExpression: aws.String("attribute_not_exists(#key)"),
+			ExpressionAttributeNames: map[string]*string{
+				"#key": aws.String("key"),
+			},
+			ReturnValues: aws.String("ALL_OLD"),
+		}).Return(nil, awserr.New("ConditionalCheckFailedException", "The conditional request failed", nil)),
+		api.EXPECT().PutItem(&dynamodb.PutItemInput{
+			TableName: aws.String("valec"),
+			Item: map[string]*dynamodb.AttributeValue{
+				"namespace": &dynamodb.AttributeValue{
+					S: aws.String(".namespaces"),
+				},
+				"key": &dynamodb.AttributeValue{
+					S: aws.String("test"),
+				},
+			},
+		}).Return(&dynamodb.PutItemOutput{}, nil),
+	)
+	client := &Client{
+		api: api,
+	}
+
+	trashed := []*secret.Trashed{
+		&secret.Trashed{
+			ID:        "test#FOO#01483326245000000000",
+			Namespace: "test",
+			Secret: &secret.Secret{
+				Key:     "FOO",
+				Value:   "bar",
+				Version: 2,
+			},
+		},
+	}
+
+	err := client.Restore("valec", "test", trashed)
+	if err == nil {
+		t.Fatalf("Error should be raised.")
+	}
+
+	expected := &secret.ConflictError{
+		Namespace: "test",
+		Keys:      []string{"FOO"},
+	}
+	if conflict, ok := errors.Cause(err).(*secret.ConflictError); !ok || !reflect.DeepEqual(conflict, expected) {
+		t.Errorf("Conflict does not match. expected: %#v, actual: %#v", expected, err)
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	testcases := []struct {
+		retry    int
+		expected time.Duration
+	}{
+		{
+			retry:    0,
+			expected: 100 * time.Millisecond,
+		},
+		{
+			retry:    3,
+			expected: 800 * time.Millisecond,
+		},
+		{
+			retry:    8,
+			expected: 20 * time.Second,
+		},
+		{
+			retry:    100,
+			expected: 20 * time.Second,
+		},
+	}
+
+	for _, tc := range testcases {
+		if actual := retryBackoff(tc.retry); actual != tc.expected {
+			t.Errorf("Backoff does not match. retry: %d, expected: %s, actual: %s", tc.retry, tc.expected, actual)
+		}
+	}
+}
+
+func TestRandomWait(t *testing.T) {
+	max := 100 * time.Millisecond
+
+	for i := 0; i < 100; i++ {
+		if actual := randomWait(max); actual < 0 || actual >= max {
+			t.Errorf("Wait should be shorter than %s. actual: %s", max, actual)
+		}
+	}
+}
+
+func TestSetMaxRetries(t *testing.T) {
+	client := NewClient(nil)
+
+	if err := client.SetMaxRetries(10); err != nil {
+		t.Errorf("Error should not be raised. error: %s", err)
+	}
+
+	if client.maxRetries != 10 {
+		t.Errorf("Max retries does not match. expected: %d, actual: %d", 10, client.maxRetries)
+	}
+
+	for _, maxRetries := range []int{-1, MaxRetriesLimit + 1} {
+		if err := client.SetMaxRetries(maxRetries); err == nil {
+			t.Errorf("Error should be raised. maxRetries: %d", maxRetries)
+		}
+	}
+
+	if client.maxRetries != 10 {
+		t.Errorf("Max retries should not be changed by invalid value. expected: %d, actual: %d", 10, client.maxRetries)
+	}
+}
+
 func TestTableExists(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -981,3 +4001,38 @@ func TestTableExists(t *testing.T) {
 		}
 	}
 }
+
+func TestTableExists_multiplePages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := mock.NewMockDynamoDBAPI(ctrl)
+
+	gomock.InOrder(
+		api.EXPECT().ListTables(&dynamodb.ListTablesInput{}).Return(&dynamodb.ListTablesOutput{
+			TableNames: []*string{
+				aws.String("foo"),
+			},
+			LastEvaluatedTableName: aws.String("foo"),
+		}, nil),
+		api.EXPECT().ListTables(&dynamodb.ListTablesInput{
+			ExclusiveStartTableName: aws.String("foo"),
+		}).Return(&dynamodb.ListTablesOutput{
+			TableNames: []*string{
+				aws.String("valec"),
+			},
+		}, nil),
+	)
+	client := &Client{
+		api: api,
+	}
+
+	actual, err := client.TableExists("valec")
+	if err != nil {
+		t.Errorf("Error should not be raised. error: %s", err)
+	}
+
+	if !actual {
+		t.Errorf("Result does not match. table: %s, expected: %t", "valec", true)
+	}
+}
@@ -2,13 +2,25 @@ package kms
 
 import (
 	"encoding/base64"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/kms"
 	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
 	"github.com/pkg/errors"
 )
 
+const (
+	// maxThrottlingRetries is the number of retries for throttled requests
+	maxThrottlingRetries = 5
+	// throttlingBackoffBase is the wait before the first retry, doubled on each retry
+	throttlingBackoffBase = 100 * time.Millisecond
+)
+
+// sleep is replaced in tests to avoid waiting
+var sleep = time.Sleep
+
 // Client represents the wrapper of KMS API client
 type Client struct {
 	api kmsiface.KMSAPI
@@ -47,42 +59,156 @@ func (c *Client) CreateKeyAlias(keyID, keyAlias string) error {
 }
 
 // DecryptBase64 decrypts the given base64-encoded cipher text
-func (c *Client) DecryptBase64(key, cipherText string) (string, error) {
+// Empty namespace means the cipher text is not bound to any namespace.
+func (c *Client) DecryptBase64(namespace, key, cipherText string) (string, error) {
 	decoded, err := base64.StdEncoding.DecodeString(cipherText)
 	if err != nil {
 		return "", errors.Wrapf(err, "Failed to decode as base64 string. text=%q", cipherText)
 	}
 
-	resp, err := c.api.Decrypt(&kms.DecryptInput{
-		CiphertextBlob: decoded,
-		EncryptionContext: map[string]*string{
-			"key": aws.String(key),
-		},
+	resp, err := c.decrypt(&kms.DecryptInput{
+		CiphertextBlob:    decoded,
+		EncryptionContext: encryptionContext(namespace, key),
 	})
 	if err != nil {
-		return "", errors.Wrapf(err, "Failed to decrypt the given cipherText. key=%s, cipherText=%q", key, cipherText)
+		return "", errors.Wrapf(err, "Failed to decrypt the given cipherText. namespace=%s, key=%s, cipherText=%q", namespace, key, cipherText)
 	}
 
 	return string(resp.Plaintext), nil
 }
 
+// DecryptDataKey decrypts the given base64-encoded data key
+func (c *Client) DecryptDataKey(dataKey string) ([]byte, error) {
+	decoded, err := base64.StdEncoding.DecodeString(dataKey)
+	if err != nil {
+		return []byte{}, errors.Wrapf(err, "Failed to decode as base64 string. text=%q", dataKey)
+	}
+
+	resp, err := c.decrypt(&kms.DecryptInput{
+		CiphertextBlob: decoded,
+	})
+	if err != nil {
+		return []byte{}, errors.Wrap(err, "Failed to decrypt data key.")
+	}
+
+	return resp.Plaintext, nil
+}
+
 // EncryptBase64 encrypts the given text and return as base64-encoded cipher text
-func (c *Client) EncryptBase64(keyAlias, key, text string) (string, error) {
+// Cipher text is bound to the given namespace and key through encryption context.
+func (c *Client) EncryptBase64(keyAlias, namespace, key, text string) (string, error) {
 	resp, err := c.api.Encrypt(&kms.EncryptInput{
+		KeyId:             aws.String(keyAliasWithPrefix(keyAlias)),
+		Plaintext:         []byte(text),
+		EncryptionContext: encryptionContext(namespace, key),
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "Failed to encrypt text. keyAlias=%s, namespace=%s, key=%q", keyAlias, namespace, key)
+	}
+
+	return base64.StdEncoding.EncodeToString(resp.CiphertextBlob), nil
+}
+
+// GenerateDataKey generates new 256-bit data key
+// Plain data key and base64-encoded data key encrypted with the given key are returned.
+func (c *Client) GenerateDataKey(keyAlias string) ([]byte, string, error) {
+	resp, err := c.api.GenerateDataKey(&kms.GenerateDataKeyInput{
+		KeyId:   aws.String(keyAliasWithPrefix(keyAlias)),
+		KeySpec: aws.String(kms.DataKeySpecAes256),
+	})
+	if err != nil {
+		return []byte{}, "", errors.Wrapf(err, "Failed to generate data key. keyAlias=%s", keyAlias)
+	}
+
+	return resp.Plaintext, base64.StdEncoding.EncodeToString(resp.CiphertextBlob), nil
+}
+
+// ReEncryptBase64 re-encrypts the given base64-encoded cipher text with the given key
+// Plain text never leaves KMS. Encryption context of the cipher text is kept as it is.
+func (c *Client) ReEncryptBase64(keyAlias, namespace, key, cipherText string) (string, error) {
+	decoded, err := base64.StdEncoding.DecodeString(cipherText)
+	if err != nil {
+		return "", errors.Wrapf(err, "Failed to decode as base64 string. text=%q", cipherText)
+	}
+
+	resp, err := c.api.ReEncrypt(&kms.ReEncryptInput{
+		CiphertextBlob:               decoded,
+		DestinationEncryptionContext: encryptionContext(namespace, key),
+		DestinationKeyId:             aws.String(keyAliasWithPrefix(keyAlias)),
+		SourceEncryptionContext:      encryptionContext(namespace, key),
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "Failed to re-encrypt the given cipherText. keyAlias=%s, namespace=%s, key=%s", keyAlias, namespace, key)
+	}
+
+	return base64.StdEncoding.EncodeToString(resp.CiphertextBlob), nil
+}
+
+// ReEncryptDataKey re-encrypts the given base64-encoded data key with the given key
+func (c *Client) ReEncryptDataKey(keyAlias, dataKey string) (string, error) {
+	decoded, err := base64.StdEncoding.DecodeString(dataKey)
+	if err != nil {
+		return "", errors.Wrapf(err, "Failed to decode as base64 string. text=%q", dataKey)
+	}
 
-		KeyId:     aws.String(keyAliasWithPrefix(keyAlias)),
-		Plaintext: []byte(text),
-		EncryptionContext: map[string]*string{
-			"key": aws.String(key),
-		},
+	resp, err := c.api.ReEncrypt(&kms.ReEncryptInput{
+		CiphertextBlob:   decoded,
+		DestinationKeyId: aws.String(keyAliasWithPrefix(keyAlias)),
 	})
 	if err != nil {
-		return "", errors.Wrapf(err, "Failed to encrypt text. keyAlias=%s, key=%q, text=%q", keyAlias, key, text)
+		return "", errors.Wrapf(err, "Failed to re-encrypt data key. keyAlias=%s", keyAlias)
 	}
 
 	return base64.StdEncoding.EncodeToString(resp.CiphertextBlob), nil
 }
 
+// KeyARN returns ARN of the key which the given key alias points to
+func (c *Client) KeyARN(keyAlias string) (string, error) {
+	resp, err := c.api.DescribeKey(&kms.DescribeKeyInput{
+		KeyId: aws.String(keyAliasWithPrefix(keyAlias)),
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "Failed to describe key. keyAlias=%s", keyAlias)
+	}
+
+	return *resp.KeyMetadata.Arn, nil
+}
+
+// KeyARNOfCipherText returns ARN of the key which encrypted the given base64-encoded cipher text
+func (c *Client) KeyARNOfCipherText(namespace, key, cipherText string) (string, error) {
+	decoded, err := base64.StdEncoding.DecodeString(cipherText)
+	if err != nil {
+		return "", errors.Wrapf(err, "Failed to decode as base64 string. text=%q", cipherText)
+	}
+
+	resp, err := c.decrypt(&kms.DecryptInput{
+		CiphertextBlob:    decoded,
+		EncryptionContext: encryptionContext(namespace, key),
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "Failed to decrypt the given cipherText. namespace=%s, key=%s, cipherText=%q", namespace, key, cipherText)
+	}
+
+	return *resp.KeyId, nil
+}
+
+// KeyARNOfDataKey returns ARN of the key which encrypted the given base64-encoded data key
+func (c *Client) KeyARNOfDataKey(dataKey string) (string, error) {
+	decoded, err := base64.StdEncoding.DecodeString(dataKey)
+	if err != nil {
+		return "", errors.Wrapf(err, "Failed to decode as base64 string. text=%q", dataKey)
+	}
+
+	resp, err := c.decrypt(&kms.DecryptInput{
+		CiphertextBlob: decoded,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to decrypt data key.")
+	}
+
+	return *resp.KeyId, nil
+}
+
 // KeyExists checks whether the given key exists or not
 func (c *Client) KeyExists(keyAlias string) (bool, error) {
 	resp, err := c.api.ListAliases(&kms.ListAliasesInput{})
@@ -101,8 +227,37 @@ func (c *Client) KeyExists(keyAlias string) (bool, error) {
 	return false, nil
 }
 
+// decrypt calls Decrypt API, retrying with exponential backoff while the request is throttled
+func (c *Client) decrypt(input *kms.DecryptInput) (*kms.DecryptOutput, error) {
+	for i := 0; ; i++ {
+		resp, err := c.api.Decrypt(input)
+		if err == nil || i >= maxThrottlingRetries || !isThrottled(err) {
+			return resp, err
+		}
+
+		sleep(throttlingBackoffBase << uint(i))
+	}
+}
+
+func isThrottled(err error) bool {
+	aerr, ok := err.(awserr.Error)
+	return ok && aerr.Code() == "ThrottlingException"
+}
+
 func keyAliasWithPrefix(keyAlias string) string {
 	// To use alias instead of KeyId, prefix 'alias/' is needed.
 	// https://docs.aws.amazon.com/kms/latest/developerguide/programming-aliases.html
 	return "alias/" + keyAlias
 }
+
+func encryptionContext(namespace, key string) map[string]*string {
+	ctx := map[string]*string{
+		"key": aws.String(key),
+	}
+
+	if namespace != "" {
+		ctx["namespace"] = aws.String(namespace)
+	}
+
+	return ctx
+}
@@ -1,9 +1,12 @@
 package kms
 
 import (
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/kms"
 	"github.com/dtan4/valec/aws/mock"
 	"github.com/golang/mock/gomock"
@@ -70,11 +73,343 @@ func TestCreateKeyAlias(t *testing.T) {
 }
 
 func TestDecryptBase64(t *testing.T) {
-	t.SkipNow()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := mock.NewMockKMSAPI(ctrl)
+	api.EXPECT().Decrypt(&kms.DecryptInput{
+		CiphertextBlob: []byte("encrypted"),
+		EncryptionContext: map[string]*string{
+			"key":       aws.String("FOO"),
+			"namespace": aws.String("production"),
+		},
+	}).Return(&kms.DecryptOutput{
+		Plaintext: []byte("bar"),
+	}, nil)
+	client := &Client{
+		api: api,
+	}
+
+	actual, err := client.DecryptBase64("production", "FOO", "ZW5jcnlwdGVk")
+	if err != nil {
+		t.Errorf("Error should not be raised. error: %s", err)
+	}
+
+	if actual != "bar" {
+		t.Errorf("Plain text does not match. expected: %q, actual: %q", "bar", actual)
+	}
+}
+
+func TestDecryptBase64_throttled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	waits := []time.Duration{}
+	sleep = func(d time.Duration) {
+		waits = append(waits, d)
+	}
+	defer func() {
+		sleep = time.Sleep
+	}()
+
+	input := &kms.DecryptInput{
+		CiphertextBlob: []byte("encrypted"),
+		EncryptionContext: map[string]*string{
+			"key":       aws.String("FOO"),
+			"namespace": aws.String("production"),
+		},
+	}
+	throttled := awserr.New("ThrottlingException", "Rate exceeded", nil)
+
+	api := mock.NewMockKMSAPI(ctrl)
+	gomock.InOrder(
+		api.EXPECT().Decrypt(input).Return(nil, throttled),
+		api.EXPECT().Decrypt(input).Return(nil, throttled),
+		api.EXPECT().Decrypt(input).Return(&kms.DecryptOutput{
+			Plaintext: []byte("bar"),
+		}, nil),
+	)
+	client := &Client{
+		api: api,
+	}
+
+	actual, err := client.DecryptBase64("production", "FOO", "ZW5jcnlwdGVk")
+	if err != nil {
+		t.Errorf("Error should not be raised. error: %s", err)
+	}
+
+	if actual != "bar" {
+		t.Errorf("Plain text does not match. expected: %q, actual: %q", "bar", actual)
+	}
+
+	expected := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond}
+	if !reflect.DeepEqual(waits, expected) {
+		t.Errorf("Backoff does not match. expected: %v, actual: %v", expected, waits)
+	}
+}
+
+func TestDecryptBase64_throttledTooManyTimes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sleep = func(d time.Duration) {}
+	defer func() {
+		sleep = time.Sleep
+	}()
+
+	api := mock.NewMockKMSAPI(ctrl)
+	api.EXPECT().Decrypt(gomock.Any()).Return(nil, awserr.New("ThrottlingException", "Rate exceeded", nil)).Times(maxThrottlingRetries + 1)
+	client := &Client{
+		api: api,
+	}
+
+	if _, err := client.DecryptBase64("production", "FOO", "ZW5jcnlwdGVk"); err == nil {
+		t.Errorf("Error should be raised.")
+	}
+}
+
+func TestDecryptDataKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := mock.NewMockKMSAPI(ctrl)
+	api.EXPECT().Decrypt(&kms.DecryptInput{
+		CiphertextBlob: []byte("wrapped"),
+	}).Return(&kms.DecryptOutput{
+		Plaintext: []byte("plain"),
+	}, nil)
+	client := &Client{
+		api: api,
+	}
+
+	actual, err := client.DecryptDataKey("d3JhcHBlZA==")
+	if err != nil {
+		t.Errorf("Error should not be raised. error: %s", err)
+	}
+
+	if string(actual) != "plain" {
+		t.Errorf("Data key does not match. expected: %q, actual: %q", "plain", string(actual))
+	}
 }
 
 func TestEncryptBase64(t *testing.T) {
-	t.SkipNow()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := mock.NewMockKMSAPI(ctrl)
+	api.EXPECT().Encrypt(&kms.EncryptInput{
+		KeyId:     aws.String("alias/valec"),
+		Plaintext: []byte("bar"),
+		EncryptionContext: map[string]*string{
+			"key":       aws.String("FOO"),
+			"namespace": aws.String("production"),
+		},
+	}).Return(&kms.EncryptOutput{
+		CiphertextBlob: []byte("encrypted"),
+	}, nil)
+	api.EXPECT().Encrypt(&kms.EncryptInput{
+		KeyId:     aws.String("alias/valec"),
+		Plaintext: []byte("bar"),
+		EncryptionContext: map[string]*string{
+			"key": aws.String("FOO"),
+		},
+	}).Return(&kms.EncryptOutput{
+		CiphertextBlob: []byte("encrypted"),
+	}, nil)
+	client := &Client{
+		api: api,
+	}
+
+	testcases := []string{
+		"production",
+		"",
+	}
+
+	for _, namespace := range testcases {
+		actual, err := client.EncryptBase64("valec", namespace, "FOO", "bar")
+		if err != nil {
+			t.Errorf("Error should not be raised. error: %s", err)
+		}
+
+		if actual != "ZW5jcnlwdGVk" {
+			t.Errorf("Cipher text does not match. expected: %q, actual: %q, namespace: %q", "ZW5jcnlwdGVk", actual, namespace)
+		}
+	}
+}
+
+func TestGenerateDataKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := mock.NewMockKMSAPI(ctrl)
+	api.EXPECT().GenerateDataKey(&kms.GenerateDataKeyInput{
+		KeyId:   aws.String("alias/valec"),
+		KeySpec: aws.String("AES_256"),
+	}).Return(&kms.GenerateDataKeyOutput{
+		CiphertextBlob: []byte("wrapped"),
+		Plaintext:      []byte("plain"),
+	}, nil)
+	client := &Client{
+		api: api,
+	}
+
+	plain, wrapped, err := client.GenerateDataKey("valec")
+	if err != nil {
+		t.Errorf("Error should not be raised. error: %s", err)
+	}
+
+	if string(plain) != "plain" {
+		t.Errorf("Plain data key does not match. expected: %q, actual: %q", "plain", string(plain))
+	}
+
+	if wrapped != "d3JhcHBlZA==" {
+		t.Errorf("Wrapped data key does not match. expected: %q, actual: %q", "d3JhcHBlZA==", wrapped)
+	}
+}
+
+func TestReEncryptBase64(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := mock.NewMockKMSAPI(ctrl)
+	api.EXPECT().ReEncrypt(&kms.ReEncryptInput{
+		CiphertextBlob: []byte("encrypted"),
+		DestinationEncryptionContext: map[string]*string{
+			"key":       aws.String("FOO"),
+			"namespace": aws.String("production"),
+		},
+		DestinationKeyId: aws.String("alias/valec-new"),
+		SourceEncryptionContext: map[string]*string{
+			"key":       aws.String("FOO"),
+			"namespace": aws.String("production"),
+		},
+	}).Return(&kms.ReEncryptOutput{
+		CiphertextBlob: []byte("reencrypted"),
+	}, nil)
+	client := &Client{
+		api: api,
+	}
+
+	actual, err := client.ReEncryptBase64("valec-new", "production", "FOO", "ZW5jcnlwdGVk")
+	if err != nil {
+		t.Errorf("Error should not be raised. error: %s", err)
+	}
+
+	if actual != "cmVlbmNyeXB0ZWQ=" {
+		t.Errorf("Cipher text does not match. expected: %q, actual: %q", "cmVlbmNyeXB0ZWQ=", actual)
+	}
+}
+
+func TestReEncryptDataKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := mock.NewMockKMSAPI(ctrl)
+	api.EXPECT().ReEncrypt(&kms.ReEncryptInput{
+		CiphertextBlob:   []byte("wrapped"),
+		DestinationKeyId: aws.String("alias/valec-new"),
+	}).Return(&kms.ReEncryptOutput{
+		CiphertextBlob: []byte("rewrapped"),
+	}, nil)
+	client := &Client{
+		api: api,
+	}
+
+	actual, err := client.ReEncryptDataKey("valec-new", "d3JhcHBlZA==")
+	if err != nil {
+		t.Errorf("Error should not be raised. error: %s", err)
+	}
+
+	if actual != "cmV3cmFwcGVk" {
+		t.Errorf("Data key does not match. expected: %q, actual: %q", "cmV3cmFwcGVk", actual)
+	}
+}
+
+func TestKeyARN(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := mock.NewMockKMSAPI(ctrl)
+	api.EXPECT().DescribeKey(&kms.DescribeKeyInput{
+		KeyId: aws.String("alias/valec"),
+	}).Return(&kms.DescribeKeyOutput{
+		KeyMetadata: &kms.KeyMetadata{
+			Arn:   aws.String("arn:aws:kms:ap-northeast-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"),
+			KeyId: aws.String("1234abcd-12ab-34cd-56ef-1234567890ab"),
+		},
+	}, nil)
+	client := &Client{
+		api: api,
+	}
+
+	expected := "arn:aws:kms:ap-northeast-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"
+
+	actual, err := client.KeyARN("valec")
+	if err != nil {
+		t.Errorf("Error should not be raised. error: %s", err)
+	}
+
+	if actual != expected {
+		t.Errorf("Key ARN does not match. expected: %q, actual: %q", expected, actual)
+	}
+}
+
+func TestKeyARNOfCipherText(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := mock.NewMockKMSAPI(ctrl)
+	api.EXPECT().Decrypt(&kms.DecryptInput{
+		CiphertextBlob: []byte("encrypted"),
+		EncryptionContext: map[string]*string{
+			"key":       aws.String("FOO"),
+			"namespace": aws.String("production"),
+		},
+	}).Return(&kms.DecryptOutput{
+		KeyId:     aws.String("arn:aws:kms:ap-northeast-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"),
+		Plaintext: []byte("bar"),
+	}, nil)
+	client := &Client{
+		api: api,
+	}
+
+	expected := "arn:aws:kms:ap-northeast-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"
+
+	actual, err := client.KeyARNOfCipherText("production", "FOO", "ZW5jcnlwdGVk")
+	if err != nil {
+		t.Errorf("Error should not be raised. error: %s", err)
+	}
+
+	if actual != expected {
+		t.Errorf("Key ARN does not match. expected: %q, actual: %q", expected, actual)
+	}
+}
+
+func TestKeyARNOfDataKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := mock.NewMockKMSAPI(ctrl)
+	api.EXPECT().Decrypt(&kms.DecryptInput{
+		CiphertextBlob: []byte("wrapped"),
+	}).Return(&kms.DecryptOutput{
+		KeyId:     aws.String("arn:aws:kms:ap-northeast-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"),
+		Plaintext: []byte("plain"),
+	}, nil)
+	client := &Client{
+		api: api,
+	}
+
+	expected := "arn:aws:kms:ap-northeast-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"
+
+	actual, err := client.KeyARNOfDataKey("d3JhcHBlZA==")
+	if err != nil {
+		t.Errorf("Error should not be raised. error: %s", err)
+	}
+
+	if actual != expected {
+		t.Errorf("Key ARN does not match. expected: %q, actual: %q", expected, actual)
+	}
 }
 
 func TestKeyExists(t *testing.T) {
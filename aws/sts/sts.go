@@ -0,0 +1,29 @@
+package sts
+
+import (
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+	"github.com/pkg/errors"
+)
+
+// Client represents the wrapper of STS API client
+type Client struct {
+	api stsiface.STSAPI
+}
+
+// NewClient creates new Client object
+func NewClient(api stsiface.STSAPI) *Client {
+	return &Client{
+		api: api,
+	}
+}
+
+// CallerIdentity returns ARN of IAM user or role whose credentials are used
+func (c *Client) CallerIdentity() (string, error) {
+	resp, err := c.api.GetCallerIdentity(&sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to retrieve caller identity.")
+	}
+
+	return *resp.Arn, nil
+}
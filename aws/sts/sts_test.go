@@ -0,0 +1,63 @@
+package sts
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/dtan4/valec/aws/mock"
+	"github.com/golang/mock/gomock"
+)
+
+func TestNewClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := mock.NewMockSTSAPI(ctrl)
+	client := NewClient(api)
+
+	if client.api != api {
+		t.Errorf("client.api does not match.")
+	}
+}
+
+func TestCallerIdentity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := mock.NewMockSTSAPI(ctrl)
+	api.EXPECT().GetCallerIdentity(&sts.GetCallerIdentityInput{}).Return(&sts.GetCallerIdentityOutput{
+		Account: aws.String("123456789012"),
+		Arn:     aws.String("arn:aws:iam::123456789012:user/valec"),
+		UserId:  aws.String("AIDAEXAMPLE"),
+	}, nil)
+	client := &Client{
+		api: api,
+	}
+
+	expected := "arn:aws:iam::123456789012:user/valec"
+	actual, err := client.CallerIdentity()
+	if err != nil {
+		t.Errorf("Error should not be raised. error: %s", err)
+	}
+
+	if actual != expected {
+		t.Errorf("ARN does not match. expected: %q, actual: %q", expected, actual)
+	}
+}
+
+func TestCallerIdentity_error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := mock.NewMockSTSAPI(ctrl)
+	api.EXPECT().GetCallerIdentity(&sts.GetCallerIdentityInput{}).Return(nil, awserr.New("ExpiredToken", "The security token included in the request is expired", nil))
+	client := &Client{
+		api: api,
+	}
+
+	if _, err := client.CallerIdentity(); err == nil {
+		t.Errorf("Error should be raised.")
+	}
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"github.com/dtan4/valec/msg"
+	"github.com/dtan4/valec/plan"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// applyCmd represents the apply command
+var applyCmd = &cobra.Command{
+	Use:   "apply PLANFILE",
+	Short: "Apply changes saved by valec plan",
+	Long: `Apply changes saved by valec plan
+
+Plan is refused if DynamoDB table was changed after it was made.
+Please make plan again in that case.`,
+	RunE: doApply,
+}
+
+var applyOpts = struct {
+	protected []string
+	yes       bool
+}{}
+
+func doApply(cmd *cobra.Command, args []string) error {
+	if len(args) < 1 {
+		return errors.New("Please specify plan file.")
+	}
+	filename := args[0]
+
+	if rootOpts.noColor {
+		msg.DisableColor()
+	}
+
+	p, err := plan.Load(filename)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to load plan file. filename=%s", filename)
+	}
+
+	if err := verifyPlan(p); err != nil {
+		return errors.Wrap(err, "Plan is stale. Please run `valec plan` again.")
+	}
+
+	if err := plan.ValidatePatterns(applyOpts.protected); err != nil {
+		return err
+	}
+
+	// Namespaces protected after planning are not deleted either
+	p.Protect(applyOpts.protected)
+
+	if err := runPlan(p, plan.NewReport(p, false), runOptions{yes: applyOpts.yes, interactive: true, parallel: 1, summary: true}); err != nil {
+		return errors.Wrap(err, "Failed to apply plan.")
+	}
+
+	return nil
+}
+
+// verifyPlan checks that DynamoDB table has not been changed since the given plan was made
+func verifyPlan(p *plan.Plan) error {
+	if p.Table != rootOpts.tableName {
+		return errors.Errorf("Plan was made for another table. table=%s", p.Table)
+	}
+
+	namespaces, err := secretStore.ListNamespaces(rootOpts.tableName)
+	if err != nil {
+		return errors.Wrap(err, "Failed to retrieve namespaces.")
+	}
+
+	if plan.DigestStrings(p.Scope.Filter(namespaces)) != p.NamespacesDigest {
+		return errors.New("Namespaces were changed since the plan was made.")
+	}
+
+	for _, n := range append(p.Namespaces, p.DeletedNamespaces...) {
+		secrets, err := secretStore.ListSecrets(rootOpts.tableName, n.Name)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to retrieve secrets. namespace=%s", n.Name)
+		}
+
+		if plan.Digest(secrets) != n.RemoteDigest {
+			return errors.Errorf("Secrets were changed since the plan was made. namespace=%s", n.Name)
+		}
+	}
+
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(applyCmd)
+
+	applyCmd.Flags().StringSliceVar(&applyOpts.protected, "protect", defaultProtectedNamespaces(), "Glob patterns of namespaces never to delete")
+	applyCmd.Flags().BoolVarP(&applyOpts.yes, "yes", "y", false, "Delete namespaces without confirmation")
+}
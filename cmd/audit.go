@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"os"
+	"os/user"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dtan4/valec/audit"
+	"github.com/dtan4/valec/aws"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var auditLog = struct {
+	sync.Mutex
+	logger  *audit.Logger
+	command string
+	// keys touched by command in each namespace
+	namespaces map[string]map[string]bool
+}{}
+
+// defaultAuditLog returns the destination of audit log
+// It is read from VALEC_AUDIT_LOG environment variable, and audit log is disabled if it is empty.
+func defaultAuditLog() string {
+	return os.Getenv("VALEC_AUDIT_LOG")
+}
+
+// openAuditLog starts recording audit log of the given command if audit log is enabled
+func openAuditLog(cmd *cobra.Command) error {
+	if rootOpts.auditLog == "" {
+		return nil
+	}
+
+	l, err := audit.Open(rootOpts.auditLog)
+	if err != nil {
+		return err
+	}
+
+	auditLog.Lock()
+	defer auditLog.Unlock()
+
+	auditLog.logger = l
+	auditLog.command = cmd.CommandPath()
+	auditLog.namespaces = map[string]map[string]bool{}
+
+	return nil
+}
+
+// recordAudit records that the given keys in the given namespace were touched by command
+// Namespace without keys is recorded if no key is given.
+func recordAudit(namespace string, keys ...string) {
+	auditLog.Lock()
+	defer auditLog.Unlock()
+
+	if auditLog.logger == nil {
+		return
+	}
+
+	if _, ok := auditLog.namespaces[namespace]; !ok {
+		auditLog.namespaces[namespace] = map[string]bool{}
+	}
+
+	for _, key := range keys {
+		auditLog.namespaces[namespace][key] = true
+	}
+}
+
+// closeAuditLog writes audit log of command with the outcome of the given error and exit code
+// One line is written for each touched namespace. Audit log is written only once.
+func closeAuditLog(cmdErr error) error {
+	auditLog.Lock()
+	defer auditLog.Unlock()
+
+	if auditLog.logger == nil {
+		return nil
+	}
+
+	l := auditLog.logger
+	auditLog.logger = nil
+	defer l.Close()
+
+	outcome := audit.OutcomeSucceeded
+
+	// Commands like sync report partial failure only by exit code
+	switch {
+	case cmdErr != nil, exitCode == exitCodeFailed:
+		outcome = audit.OutcomeFailed
+	case exitCode == exitCodePending:
+		outcome = audit.OutcomePending
+	}
+
+	var identity string
+
+	if aws.STS != nil {
+		// Entries are written even if caller identity is unavailable
+		identity, _ = aws.STS.CallerIdentity()
+	}
+
+	var username string
+
+	if u, err := user.Current(); err == nil {
+		username = u.Username
+	}
+
+	namespaces := []string{}
+
+	for namespace := range auditLog.namespaces {
+		namespaces = append(namespaces, namespace)
+	}
+
+	sort.Strings(namespaces)
+
+	if len(namespaces) == 0 {
+		namespaces = []string{""}
+	}
+
+	timestamp := time.Now().UTC()
+
+	for _, namespace := range namespaces {
+		keys := []string{}
+
+		for key := range auditLog.namespaces[namespace] {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		if err := l.Write(&audit.Entry{
+			Time:      timestamp,
+			Command:   auditLog.command,
+			Table:     rootOpts.tableName,
+			Namespace: namespace,
+			Keys:      keys,
+			Identity:  identity,
+			User:      username,
+			Outcome:   outcome,
+		}); err != nil {
+			return errors.Wrapf(err, "Failed to write audit log. destination=%s", rootOpts.auditLog)
+		}
+	}
+
+	return nil
+}
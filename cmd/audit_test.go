@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dtan4/valec/audit"
+)
+
+func TestCloseAuditLog_sync(t *testing.T) {
+	testcases := []struct {
+		dryRun   bool
+		failure  error
+		expected map[string]string
+	}{
+		{
+			expected: map[string]string{
+				"fuga": audit.OutcomeSucceeded,
+				"hoge": audit.OutcomeSucceeded,
+			},
+		},
+		{
+			failure: fmt.Errorf("ProvisionedThroughputExceededException"),
+			expected: map[string]string{
+				"fuga": audit.OutcomeFailed,
+				"hoge": audit.OutcomeFailed,
+			},
+		},
+		{
+			dryRun: true,
+			expected: map[string]string{
+				"": audit.OutcomePending,
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		dir, s := setupTest(t)
+		defer os.RemoveAll(dir)
+
+		writeSecretFile(t, dir, "fuga", "fuga", "valec", map[string]string{"FOO": "bar"})
+		writeSecretFile(t, dir, "hoge", "hoge", "valec", map[string]string{"FOO": "bar"})
+
+		if tc.failure != nil {
+			s.failures["hoge"] = tc.failure
+		}
+
+		rootOpts.auditLog = filepath.Join(dir, "audit.log")
+		syncOpts.dryRun = tc.dryRun
+		syncOpts.output = outputText
+
+		if err := openAuditLog(syncCmd); err != nil {
+			t.Fatalf("Error should not be raised. error: %s", err)
+		}
+
+		err := doSync(syncCmd, []string{dir})
+
+		if err := closeAuditLog(err); err != nil {
+			t.Fatalf("Error should not be raised. error: %s", err)
+		}
+
+		body, err := ioutil.ReadFile(rootOpts.auditLog)
+		if err != nil {
+			t.Fatalf("Error should not be raised. error: %s", err)
+		}
+
+		actual := map[string]string{}
+
+		for _, line := range strings.Split(strings.TrimSpace(string(body)), "\n") {
+			var e audit.Entry
+
+			if err := json.Unmarshal([]byte(line), &e); err != nil {
+				t.Fatalf("Error should not be raised. error: %s", err)
+			}
+
+			actual[e.Namespace] = e.Outcome
+		}
+
+		if !reflect.DeepEqual(actual, tc.expected) {
+			t.Errorf("Outcome does not match. dryRun: %t, failure: %v, expected: %v, actual: %v", tc.dryRun, tc.failure, tc.expected, actual)
+		}
+	}
+
+	syncOpts.dryRun = false
+}
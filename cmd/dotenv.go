@@ -5,7 +5,6 @@ import (
 	"os"
 	"strings"
 
-	"github.com/dtan4/valec/aws"
 	"github.com/dtan4/valec/util"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -33,7 +32,9 @@ func doDotenv(cmd *cobra.Command, args []string) error {
 	}
 	namespace := args[0]
 
-	secrets, err := aws.DynamoDB.ListSecrets(rootOpts.tableName, namespace)
+	recordAudit(namespace)
+
+	secrets, err := secretStore.ListSecrets(rootOpts.tableName, namespace)
 	if err != nil {
 		return errors.Wrap(err, "Failed to retrieve secrets.")
 	}
@@ -51,7 +52,7 @@ func doDotenv(cmd *cobra.Command, args []string) error {
 		if os.IsNotExist(err) {
 			fmt.Fprintf(os.Stderr, "%s does not exist. Dumping all secrets...\n", dotenvSampleName)
 
-			dotenv, err2 = dumpAll(secrets, dotenvOpts.quote)
+			dotenv, err2 = dumpAll(namespace, secrets, dotenvOpts.quote)
 			if err2 != nil {
 				return errors.Wrap(err, "Failed to dump all secrets.")
 			}
@@ -59,7 +60,7 @@ func doDotenv(cmd *cobra.Command, args []string) error {
 			return errors.Wrapf(err, "Failed to get stat of dotenv template. filename=%s", dotenvSampleName)
 		}
 	} else {
-		dotenv, err2 = dumpWithTemplate(secrets, dotenvOpts.quote, dotenvSampleName, false)
+		dotenv, err2 = dumpWithTemplate(namespace, secrets, dotenvOpts.quote, dotenvSampleName, false)
 		if err2 != nil {
 			return errors.Wrap(err, "Failed to dump secrets with dotenv template.")
 		}
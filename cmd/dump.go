@@ -5,7 +5,7 @@ import (
 	"os"
 	"strings"
 
-	"github.com/dtan4/valec/aws"
+	"github.com/dtan4/valec/secret"
 	"github.com/dtan4/valec/util"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -13,42 +13,70 @@ import (
 
 // dumpCmd represents the dump command
 var dumpCmd = &cobra.Command{
-	Use:   "dump NAMESPACE",
+	Use:   "dump [NAMESPACE]",
 	Short: "Dump secrets in dotenv format",
-	RunE:  doDump,
+	Long: `Dump secrets in dotenv format
+
+To dump secrets stored in DynamoDB, specify namespace:
+  $ valec dump NAMESPACE
+To dump secrets stored in local file, specify file:
+  $ valec dump -f qa.yaml`,
+	RunE: doDump,
 }
 
 var dumpOpts = struct {
 	dotenvTemplate string
+	namespace      string
 	override       bool
 	output         string
 	quote          bool
+	secretFile     string
 }{}
 
 func doDump(cmd *cobra.Command, args []string) error {
-	if len(args) != 1 {
-		return errors.New("Please specify namespace.")
-	}
-	namespace := args[0]
+	var (
+		namespace string
+		secrets   []*secret.Secret
+		err       error
+	)
 
-	secrets, err := aws.DynamoDB.ListSecrets(rootOpts.tableName, namespace)
-	if err != nil {
-		return errors.Wrap(err, "Failed to retrieve secrets.")
-	}
+	if dumpOpts.secretFile == "" {
+		if len(args) != 1 {
+			return errors.New("Please specify namespace or secret file (-f FILE).")
+		}
+		namespace = args[0]
+
+		recordAudit(namespace)
+
+		secrets, err = secretStore.ListSecrets(rootOpts.tableName, namespace)
+		if err != nil {
+			return errors.Wrap(err, "Failed to retrieve secrets.")
+		}
 
-	if len(secrets) == 0 {
-		return errors.Errorf("Namespace %s does not exist.", namespace)
+		if len(secrets) == 0 {
+			return errors.Errorf("Namespace %s does not exist.", namespace)
+		}
+	} else {
+		namespace, err = namespaceOfFile(dumpOpts.secretFile, dumpOpts.namespace)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to get namespace. filename=%s", dumpOpts.secretFile)
+		}
+
+		_, secrets, err = secret.LoadFromYAML(dumpOpts.secretFile)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to load secrets from file. filename=%s", dumpOpts.secretFile)
+		}
 	}
 
 	var dotenv []string
 
 	if dumpOpts.dotenvTemplate == "" {
-		dotenv, err = dumpAll(secrets, dumpOpts.quote)
+		dotenv, err = dumpAll(namespace, secrets, dumpOpts.quote)
 		if err != nil {
 			return errors.Wrap(err, "Failed to dump all secrets.")
 		}
 	} else {
-		dotenv, err = dumpWithTemplate(secrets, dumpOpts.quote, dumpOpts.dotenvTemplate, dumpOpts.override)
+		dotenv, err = dumpWithTemplate(namespace, secrets, dumpOpts.quote, dumpOpts.dotenvTemplate, dumpOpts.override)
 		if err != nil {
 			return errors.Wrap(err, "Failed to dump secrets with dotenv template.")
 		}
@@ -88,6 +116,8 @@ func doDump(cmd *cobra.Command, args []string) error {
 func init() {
 	RootCmd.AddCommand(dumpCmd)
 
+	dumpCmd.Flags().StringVarP(&dumpOpts.secretFile, "file", "f", "", "Secret file")
+	dumpCmd.Flags().StringVar(&dumpOpts.namespace, "namespace", "", "Namespace of secret file (default: derived from file name)")
 	dumpCmd.Flags().BoolVar(&dumpOpts.override, "override", false, "Override values in existing template")
 	dumpCmd.Flags().StringVarP(&dumpOpts.output, "output", "o", "", "File to flush dotenv")
 	dumpCmd.Flags().BoolVarP(&dumpOpts.quote, "quote", "q", false, "Quote values")
@@ -3,9 +3,12 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/dtan4/valec/aws"
+	"github.com/dtan4/valec/provider"
 	"github.com/dtan4/valec/secret"
 	"github.com/dtan4/valec/util"
 	"github.com/pkg/errors"
@@ -37,7 +40,10 @@ Enter secret value interactively:
 
 var encryptOpts = struct {
 	interactive bool
-	kmsKey      string
+	kmsKeys     []string
+	namespace   string
+	provider    string
+	recipients  []string
 	secretFile  string
 }{}
 
@@ -46,23 +52,92 @@ func doEncrypt(cmd *cobra.Command, args []string) error {
 		return errors.New("Please specify KEY=VALUE.")
 	}
 
-	secretMap := map[string]string{}
+	secretMap := map[string]*secret.Secret{}
 	var err error
 
+	providerName, recipients, dataKey := encryptOpts.provider, encryptOpts.recipients, ""
+
+	kmsKeys, err := parseKMSKeys(encryptOpts.kmsKeys)
+	if err != nil {
+		return errors.Wrap(err, "Failed to parse KMS keys.")
+	}
+
+	if encryptOpts.secretFile != "" && util.IsExist(encryptOpts.secretFile) {
+		y, err2 := secret.LoadYAML(encryptOpts.secretFile)
+		if err2 != nil {
+			return errors.Wrapf(err2, "Failed to load local secret file. filename=%s", encryptOpts.secretFile)
+		}
+
+		if !cmd.Flags().Changed("provider") {
+			providerName = provider.NameOrDefault(y.Provider)
+		}
+
+		if !cmd.Flags().Changed("key") {
+			kmsKeys = y.Keys()
+		}
+
+		if !cmd.Flags().Changed("recipient") {
+			recipients = y.Recipients
+		}
+
+		dataKey = y.DataKey
+	}
+
+	namespace := encryptOpts.namespace
+
+	if namespace == "" && encryptOpts.secretFile != "" {
+		namespace, err = namespaceOfFile(encryptOpts.secretFile, "")
+		if err != nil {
+			return errors.Wrapf(err, "Failed to get namespace. filename=%s", encryptOpts.secretFile)
+		}
+
+		fmt.Fprintf(stderr, "Secrets are bound to namespace %s. Please specify --namespace to use another one.\n", namespace)
+	}
+
+	if namespace == "" {
+		fmt.Fprintln(stderr, "Secrets are not bound to any namespace. Please specify --namespace to bind.")
+	}
+
+	header := newHeader(providerName, kmsKeys, recipients)
+
+	if header.Provider == provider.Envelope {
+		if encryptOpts.secretFile == "" {
+			return errors.New("Envelope provider requires secret file to store data key. Please specify --add FILE.")
+		}
+
+		if len(kmsKeys) > 1 {
+			return errors.New("Envelope provider does not support multiple KMS keys.")
+		}
+
+		if dataKey == "" {
+			kmsClient, err := aws.KMSForRegion(kmsKeys[0].Region)
+			if err != nil {
+				return errors.Wrap(err, "Failed to initialize KMS API client.")
+			}
+
+			_, dataKey, err = kmsClient.GenerateDataKey(kmsKeys[0].Alias)
+			if err != nil {
+				return errors.Wrap(err, "Failed to generate data key.")
+			}
+		}
+
+		header.DataKey = dataKey
+	}
+
 	if args[0] == "-" {
-		secretMap, err = readFromStdin(encryptOpts.kmsKey)
+		secretMap, err = readFromStdin(header, namespace)
 		if err != nil {
 			return errors.Wrap(err, "Failed to read secret from stdin.")
 		}
 	} else {
 		if encryptOpts.interactive {
 			fmt.Println("Entered secret value will be hidden.")
-			secretMap, err = readFromArgsInteractive(args, encryptOpts.kmsKey)
+			secretMap, err = readFromArgsInteractive(args, header, namespace)
 			if err != nil {
 				return errors.Wrap(err, "Failed to read secret from args.")
 			}
 		} else {
-			secretMap, err = readFromArgs(args, encryptOpts.kmsKey)
+			secretMap, err = readFromArgs(args, header, namespace)
 			if err != nil {
 				return errors.Wrap(err, "Failed to read secret from args.")
 			}
@@ -72,7 +147,7 @@ func doEncrypt(cmd *cobra.Command, args []string) error {
 	if encryptOpts.secretFile == "" {
 		flushToStdout(secretMap)
 	} else {
-		if err := flushToFile(secretMap, encryptOpts.secretFile, encryptOpts.kmsKey); err != nil {
+		if err := flushToFile(secretMap, encryptOpts.secretFile, header); err != nil {
 			return errors.Wrapf(err, "Failed to flush secrets to file. filename=%s", encryptOpts.secretFile)
 		}
 	}
@@ -80,42 +155,127 @@ func doEncrypt(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func flushToFile(secretMap map[string]string, filename, kmsKey string) error {
-	newSecretMap := map[string]string{}
+// parseKMSKeys parses the given KMS keys formatted as ALIAS or ALIAS@REGION
+func parseKMSKeys(ss []string) ([]*secret.KMSKeyConfig, error) {
+	if len(ss) == 0 {
+		return nil, errors.New("No KMS key is given.")
+	}
+
+	kmsKeys := []*secret.KMSKeyConfig{}
+
+	for _, s := range ss {
+		ks := strings.SplitN(s, "@", 2)
+
+		if ks[0] == "" {
+			return nil, errors.Errorf("KMS key alias is empty. key=%q", s)
+		}
+
+		k := &secret.KMSKeyConfig{
+			Alias: ks[0],
+		}
+
+		if len(ks) == 2 {
+			k.Region = ks[1]
+		}
+
+		kmsKeys = append(kmsKeys, k)
+	}
+
+	return kmsKeys, nil
+}
+
+// newHeader returns secret file header which holds the given encryption settings
+// Single KMS key without region is stored as kms_key for backward compatibility.
+func newHeader(providerName string, kmsKeys []*secret.KMSKeyConfig, recipients []string) *secret.YAML {
+	header := &secret.YAML{}
+
+	switch provider.NameOrDefault(providerName) {
+	case provider.KMS:
+		// default provider is not recorded
+	case provider.Age:
+		return &secret.YAML{
+			Provider:   providerName,
+			Recipients: recipients,
+		}
+	case provider.Envelope:
+		header.Provider = providerName
+	default:
+		return &secret.YAML{
+			Provider: providerName,
+		}
+	}
+
+	if len(kmsKeys) == 1 && kmsKeys[0].Region == "" {
+		header.KMSKey = kmsKeys[0].Alias
+	} else {
+		header.KMSKeys = kmsKeys
+	}
+
+	return header
+}
+
+func flushToFile(secretMap map[string]*secret.Secret, filename string, header *secret.YAML) error {
+	newSecretMap := map[string]*secret.Secret{}
 
 	if _, err := os.Stat(filename); err == nil {
-		currentKmsKey, secrets, err2 := secret.LoadFromYAML(filename)
+		y, err2 := secret.LoadYAML(filename)
 		if err2 != nil {
 			return errors.Wrapf(err2, "Failed to load local secret file. filename=%s", filename)
 		}
 
-		if kmsKey != currentKmsKey {
-			return errors.Errorf("KMS key alias does not match. current: %s, given: %s", currentKmsKey, kmsKey)
+		if provider.NameOrDefault(header.Provider) != provider.NameOrDefault(y.Provider) {
+			return errors.Errorf("Provider does not match. current: %s, given: %s", provider.NameOrDefault(y.Provider), provider.NameOrDefault(header.Provider))
+		}
+
+		if !reflect.DeepEqual(header.Keys(), y.Keys()) {
+			return errors.Errorf("KMS key alias does not match. Please try `valec rotate` to change key. current: %s, given: %s", kmsKeyAliases(y), kmsKeyAliases(header))
 		}
 
-		newSecretMap = secrets.ListToMap()
+		if strings.Join(header.Recipients, ",") != strings.Join(y.Recipients, ",") {
+			return errors.Errorf("Recipients do not match. current: %s, given: %s", strings.Join(y.Recipients, ","), strings.Join(header.Recipients, ","))
+		}
+
+		for _, s := range y.Secrets {
+			newSecretMap[s.Key] = s
+		}
 	}
 
 	for k, v := range secretMap {
 		newSecretMap[k] = v
 	}
-	newSecrets := secret.MapToList(newSecretMap)
 
-	if err := newSecrets.SaveAsYAML(filename, kmsKey); err != nil {
+	secrets := secret.Secrets{}
+
+	for _, s := range newSecretMap {
+		secrets = append(secrets, s)
+	}
+
+	sort.Sort(secrets)
+
+	y := &secret.YAML{
+		Provider:   header.Provider,
+		KMSKey:     header.KMSKey,
+		KMSKeys:    header.KMSKeys,
+		DataKey:    header.DataKey,
+		Recipients: header.Recipients,
+		Secrets:    secrets,
+	}
+
+	if err := y.Save(filename); err != nil {
 		return errors.Wrapf(err, "Failed to update local secret file. filename=%s", filename)
 	}
 
 	return nil
 }
 
-func flushToStdout(secretMap map[string]string) {
+func flushToStdout(secretMap map[string]*secret.Secret) {
 	for _, v := range secretMap {
-		fmt.Println(v)
+		fmt.Println(v.Value)
 	}
 }
 
-func readFromStdin(kmsKey string) (map[string]string, error) {
-	secretMap := map[string]string{}
+func readFromStdin(header *secret.YAML, namespace string) (map[string]*secret.Secret, error) {
+	secretMap := map[string]*secret.Secret{}
 	lines := util.ScanLines(os.Stdin)
 
 	for _, line := range lines {
@@ -125,51 +285,51 @@ func readFromStdin(kmsKey string) (map[string]string, error) {
 		}
 		key, value := ss[0], ss[1]
 
-		cipherText, err := aws.KMS.EncryptBase64(kmsKey, key, value)
+		encrypted, err := encryptSecret(header, namespace, key, value)
 		if err != nil {
-			return map[string]string{}, errors.Wrapf(err, "Failed to encrypt secret. key=%s", key)
+			return map[string]*secret.Secret{}, errors.Wrapf(err, "Failed to encrypt secret. key=%s", key)
 		}
 
-		secretMap[key] = cipherText
+		secretMap[key] = encrypted
 	}
 
 	return secretMap, nil
 }
 
-func readFromArgs(args []string, kmsKey string) (map[string]string, error) {
-	secretMap := map[string]string{}
+func readFromArgs(args []string, header *secret.YAML, namespace string) (map[string]*secret.Secret, error) {
+	secretMap := map[string]*secret.Secret{}
 
 	for _, arg := range args {
 		ss := strings.SplitN(arg, "=", 2)
 		if len(ss) < 2 {
-			return map[string]string{}, errors.Errorf("Given argument is invalid format, should be KEY=VALUE. argument=%q", arg)
+			return map[string]*secret.Secret{}, errors.Errorf("Given argument is invalid format, should be KEY=VALUE. argument=%q", arg)
 		}
 		key, value := ss[0], ss[1]
 
-		cipherText, err := aws.KMS.EncryptBase64(encryptOpts.kmsKey, key, value)
+		encrypted, err := encryptSecret(header, namespace, key, value)
 		if err != nil {
-			return map[string]string{}, errors.Wrapf(err, "Failed to encrypt secret. key=%s", key)
+			return map[string]*secret.Secret{}, errors.Wrapf(err, "Failed to encrypt secret. key=%s", key)
 		}
 
-		secretMap[key] = cipherText
+		secretMap[key] = encrypted
 	}
 
 	return secretMap, nil
 }
 
-func readFromArgsInteractive(args []string, kmsKey string) (map[string]string, error) {
-	secretMap := map[string]string{}
+func readFromArgsInteractive(args []string, header *secret.YAML, namespace string) (map[string]*secret.Secret, error) {
+	secretMap := map[string]*secret.Secret{}
 
 	for _, arg := range args {
 		key := arg
 		value := util.ScanNoecho(key)
 
-		cipherText, err := aws.KMS.EncryptBase64(kmsKey, key, value)
+		encrypted, err := encryptSecret(header, namespace, key, value)
 		if err != nil {
-			return map[string]string{}, errors.Wrapf(err, "Failed to encrypt secret. key=%s", key)
+			return map[string]*secret.Secret{}, errors.Wrapf(err, "Failed to encrypt secret. key=%s", key)
 		}
 
-		secretMap[key] = cipherText
+		secretMap[key] = encrypted
 	}
 
 	return secretMap, nil
@@ -180,5 +340,8 @@ func init() {
 
 	encryptCmd.Flags().StringVar(&encryptOpts.secretFile, "add", "", "Add to local secret file")
 	encryptCmd.Flags().BoolVarP(&encryptOpts.interactive, "interactive", "i", false, "Interactive value input")
-	encryptCmd.Flags().StringVarP(&encryptOpts.kmsKey, "key", "k", secret.DefaultKMSKey, "KMS key alias")
+	encryptCmd.Flags().StringSliceVarP(&encryptOpts.kmsKeys, "key", "k", []string{secret.DefaultKMSKey}, "KMS key alias (ALIAS or ALIAS@REGION, can be specified multiple times)")
+	encryptCmd.Flags().StringVar(&encryptOpts.namespace, "namespace", "", "Namespace to bind secrets (default: derived from --add file name)")
+	encryptCmd.Flags().StringVar(&encryptOpts.provider, "provider", provider.DefaultProvider, "Encryption provider")
+	encryptCmd.Flags().StringSliceVar(&encryptOpts.recipients, "recipient", []string{}, "Recipient of age provider (age1...)")
 }
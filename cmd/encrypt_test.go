@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dtan4/valec/provider"
+	"github.com/dtan4/valec/secret"
+)
+
+func TestFlushToFile_recipients(t *testing.T) {
+	dir, _ := setupTest(t)
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "hoge.yaml")
+	secretMap := map[string]*secret.Secret{
+		"FOO": &secret.Secret{Key: "FOO", Value: "bar"},
+	}
+
+	header := newHeader(provider.Age, nil, []string{"age1old"})
+	if err := flushToFile(secretMap, filename, header); err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
+	}
+
+	header = newHeader(provider.Age, nil, []string{"age1new"})
+	if err := flushToFile(secretMap, filename, header); err == nil {
+		t.Errorf("Error should be raised when recipients do not match.")
+	}
+
+	y, err := secret.LoadYAML(filename)
+	if err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
+	}
+
+	if len(y.Recipients) != 1 || y.Recipients[0] != "age1old" {
+		t.Errorf("Recipients should not be changed. actual: %q", y.Recipients)
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"os/exec"
 	"syscall"
 
-	"github.com/dtan4/valec/aws"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
@@ -27,18 +26,20 @@ func doExec(cmd *cobra.Command, args []string) error {
 	}
 	namespace := args[0]
 
-	secrets, err := aws.DynamoDB.ListSecrets(rootOpts.tableName, namespace)
+	recordAudit(namespace)
+
+	secrets, err := secretStore.ListSecrets(rootOpts.tableName, namespace)
 	if err != nil {
 		return errors.Wrapf(err, "Failed to load secrets from DynamoDB. namespace=%s", namespace)
 	}
 
-	for _, secret := range secrets {
-		plainValue, err := aws.KMS.DecryptBase64(secret.Key, secret.Value)
-		if err != nil {
-			return errors.Wrapf(err, "Failed to decrypt value. key=%q, value=%q", secret.Key, secret.Value)
-		}
+	plainValues, err := decryptSecrets(namespace, secrets)
+	if err != nil {
+		return errors.Wrap(err, "Failed to decrypt values.")
+	}
 
-		if err := os.Setenv(secret.Key, plainValue); err != nil {
+	for i, secret := range secrets {
+		if err := os.Setenv(secret.Key, plainValues[i]); err != nil {
 			return errors.Wrapf(err, "Failed to set new enviornment variable. key=%q")
 		}
 	}
@@ -54,6 +55,11 @@ func doExec(cmd *cobra.Command, args []string) error {
 		return errors.Wrap(err, "Failed to execute command.")
 	}
 
+	// os.Exit skips PersistentPostRunE, so audit log is written here
+	if err := closeAuditLog(err); err != nil {
+		return err
+	}
+
 	os.Exit(execCmd.ProcessState.Sys().(syscall.WaitStatus).ExitStatus())
 
 	return nil
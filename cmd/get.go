@@ -3,7 +3,6 @@ package cmd
 import (
 	"fmt"
 
-	"github.com/dtan4/valec/aws"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
@@ -21,12 +20,14 @@ func doGet(cmd *cobra.Command, args []string) error {
 	}
 	namespace, key := args[0], args[1]
 
-	secret, err := aws.DynamoDB.Get(rootOpts.tableName, namespace, key)
+	recordAudit(namespace, key)
+
+	secret, err := secretStore.Get(rootOpts.tableName, namespace, key)
 	if err != nil {
 		return errors.Wrap(err, "Failed to retrieve secret.")
 	}
 
-	plainValue, err := aws.KMS.DecryptBase64(secret.Key, secret.Value)
+	plainValue, err := decryptSecret(namespace, secret)
 	if err != nil {
 		return errors.Wrap(err, "Failed to decrypt secret.")
 	}
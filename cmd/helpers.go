@@ -2,48 +2,656 @@ package cmd
 
 import (
 	"bufio"
+	"crypto/rand"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 
+	"github.com/dtan4/valec/age"
 	"github.com/dtan4/valec/aws"
+	"github.com/dtan4/valec/envelope"
+	"github.com/dtan4/valec/msg"
+	"github.com/dtan4/valec/provider"
 	"github.com/dtan4/valec/secret"
+	"github.com/dtan4/valec/util"
 	"github.com/pkg/errors"
 )
 
-func dumpAll(secrets secret.Secrets, quote bool) ([]string, error) {
-	dotenv := []string{}
+// newCipher returns encryption provider configured by the given secret file header
+// KMS API client for the given region is used by providers which depend on KMS.
+func newCipher(header *secret.YAML, region string) (provider.Cipher, error) {
+	switch provider.NameOrDefault(header.Provider) {
+	case provider.Age:
+		return age.NewClient(header.Recipients, rootOpts.identityFile), nil
+	case provider.Envelope:
+		kmsClient, err := aws.KMSForRegion(region)
+		if err != nil {
+			return nil, err
+		}
 
-	for _, secret := range secrets {
-		plainValue, err := aws.KMS.DecryptBase64(secret.Key, secret.Value)
+		return envelope.NewClient(kmsClient, header.DataKey), nil
+	case provider.KMS:
+		return aws.KMSForRegion(region)
+	default:
+		return nil, errors.Errorf("Unknown provider. provider=%s", header.Provider)
+	}
+}
+
+var ciphers = struct {
+	sync.Mutex
+	m map[string]provider.Cipher
+}{
+	m: map[string]provider.Cipher{},
+}
+
+// cipherFor returns encryption provider configured by the given secret file header
+// Providers are cached so that data keys and identities are loaded only once.
+func cipherFor(header *secret.YAML, region string) (provider.Cipher, error) {
+	id := strings.Join(append([]string{provider.NameOrDefault(header.Provider), header.DataKey, region}, header.Recipients...), "\x00")
+
+	ciphers.Lock()
+	defer ciphers.Unlock()
+
+	if c, ok := ciphers.m[id]; ok {
+		return c, nil
+	}
+
+	c, err := newCipher(header, region)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to initialize encryption provider.")
+	}
+
+	ciphers.m[id] = c
+
+	return c, nil
+}
+
+// cipherTextsOf returns all cipher texts of the given secret
+// The first one is encrypted with the primary KMS key, and the rest are replicas.
+func cipherTextsOf(s *secret.Secret) []*secret.Replica {
+	return append([]*secret.Replica{
+		&secret.Replica{
+			Region: s.Region,
+			Value:  s.Value,
+			KeyARN: s.KeyARN,
+		},
+	}, s.Replicas...)
+}
+
+// decryptSecret decrypts the given secret with the provider which encrypted it
+// Secrets encrypted before namespace was bound to encryption context are also accepted.
+func decryptSecret(namespace string, s *secret.Secret) (string, error) {
+	recordAudit(namespace, s.Key)
+
+	plainValue, err := decryptSecretInNamespace(namespace, s)
+	if err == nil || namespace == "" {
+		return plainValue, err
+	}
+
+	if plainValue, err2 := decryptSecretInNamespace("", s); err2 == nil {
+		warnUnbound(namespace, s.Key)
+		return plainValue, nil
+	}
+
+	return "", err
+}
+
+var unboundWarnings = struct {
+	sync.Mutex
+	m map[string]bool
+}{
+	m: map[string]bool{},
+}
+
+// warnUnbound warns that the given secret is not bound to its namespace, once for each secret
+// Such secrets can be decrypted even if they are copied to another namespace.
+func warnUnbound(namespace, key string) {
+	id := namespace + "\x00" + key
+
+	unboundWarnings.Lock()
+	defer unboundWarnings.Unlock()
+
+	if unboundWarnings.m[id] {
+		return
+	}
+
+	unboundWarnings.m[id] = true
+
+	msg.Fprintf(stderr, msg.Yellow, "Warning: secret is not bound to namespace. Run valec migrate-context to bind it. namespace=%s, key=%s\n", namespace, key)
+}
+
+// secretState represents how the given secret is encrypted
+type secretState struct {
+	plainValue string
+	// bound is false if the secret is not bound to its namespace yet
+	bound bool
+	// keyARNs are ARNs of KMS keys which encrypted cipher texts of KMS provider
+	keyARNs []string
+}
+
+// inspectSecret decrypts the given secret and returns how it is encrypted
+func inspectSecret(namespace string, s *secret.Secret) (*secretState, error) {
+	recordAudit(namespace, s.Key)
+
+	state := &secretState{
+		bound: true,
+	}
+
+	plainValue, err := decryptSecretInNamespace(namespace, s)
+	if err != nil && namespace != "" {
+		if plainValue2, err2 := decryptSecretInNamespace("", s); err2 == nil {
+			plainValue, state.bound, err = plainValue2, false, nil
+			warnUnbound(namespace, s.Key)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	state.plainValue = plainValue
+
+	contextNamespace := namespace
+	if !state.bound {
+		contextNamespace = ""
+	}
+
+	for _, cipherText := range cipherTextsOf(s) {
+		name, body := provider.Parse(cipherText.Value)
+		if name != provider.KMS {
+			continue
+		}
+
+		kmsClient, err := aws.KMSForRegion(cipherText.Region)
+		if err != nil {
+			return nil, err
+		}
+
+		arn, err := kmsClient.KeyARNOfCipherText(contextNamespace, s.Key, body)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to retrieve KMS key of secret. key=%s", s.Key)
+		}
+
+		state.keyARNs = append(state.keyARNs, arn)
+	}
+
+	return state, nil
+}
+
+// sameEncryption returns whether two secrets are encrypted in the same way
+func (s *secretState) sameEncryption(other *secretState) bool {
+	if s.bound != other.bound || len(s.keyARNs) != len(other.keyARNs) {
+		return false
+	}
+
+	for i := range s.keyARNs {
+		if s.keyARNs[i] != other.keyARNs[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// unboundSecrets returns keys of the given secrets which are not bound to the given namespace
+func unboundSecrets(namespace string, secrets []*secret.Secret) []string {
+	unbound := make([]bool, len(secrets))
+
+	util.Parallel(len(secrets), rootOpts.concurrency, func(i int) error {
+		_, err := decryptSecretInNamespace(namespace, secrets[i])
+		unbound[i] = err != nil
+
+		return nil
+	})
+
+	keys := []string{}
+
+	for i, s := range secrets {
+		if unbound[i] {
+			keys = append(keys, s.Key)
+		}
+	}
+
+	return keys
+}
+
+var fingerprintSecret = struct {
+	sync.Once
+	b []byte
+}{}
+
+// fingerprint returns fingerprint of the given plain value
+// Secret key of fingerprints is generated on every run, so fingerprints can be compared only within
+// the same output, and plain values cannot be guessed from them offline.
+func fingerprint(plainValue string) string {
+	fingerprintSecret.Do(func() {
+		b := make([]byte, 32)
+		if _, err := rand.Read(b); err == nil {
+			fingerprintSecret.b = b
+		}
+	})
+
+	if fingerprintSecret.b == nil {
+		return "changed"
+	}
+
+	return util.Fingerprint(fingerprintSecret.b, plainValue)
+}
+
+// decryptSecrets decrypts the given secrets concurrently
+// Plain values are returned in the same order as the given secrets.
+func decryptSecrets(namespace string, secrets []*secret.Secret) ([]string, error) {
+	plainValues := make([]string, len(secrets))
+
+	if err := util.Parallel(len(secrets), rootOpts.concurrency, func(i int) error {
+		plainValue, err := decryptSecret(namespace, secrets[i])
+		if err != nil {
+			return errors.Wrapf(err, "Failed to decrypt value. key=%s", secrets[i].Key)
+		}
+
+		plainValues[i] = plainValue
+
+		return nil
+	}); err != nil {
+		return []string{}, err
+	}
+
+	return plainValues, nil
+}
+
+// decryptSecretInNamespace decrypts the given secret only if it is bound to the given namespace
+// Replicas are tried in order if the primary cipher text cannot be decrypted.
+func decryptSecretInNamespace(namespace string, s *secret.Secret) (string, error) {
+	var err error
+
+	for _, cipherText := range cipherTextsOf(s) {
+		plainValue, err2 := decryptCipherText(namespace, s, cipherText)
+		if err2 == nil {
+			return plainValue, nil
+		}
+
+		if err == nil {
+			err = err2
+		}
+	}
+
+	return "", err
+}
+
+// decryptCipherText decrypts the given cipher text of the given secret
+func decryptCipherText(namespace string, s *secret.Secret, cipherText *secret.Replica) (string, error) {
+	name, body := provider.Parse(cipherText.Value)
+
+	c, err := cipherFor(&secret.YAML{
+		Provider: name,
+		DataKey:  s.DataKey,
+	}, cipherText.Region)
+	if err != nil {
+		return "", err
+	}
+
+	return c.DecryptBase64(namespace, s.Key, body)
+}
+
+// encryptSecret encrypts the given text with the provider configured by the given secret file header
+// If multiple KMS keys are declared, the text is encrypted with each key.
+func encryptSecret(header *secret.YAML, namespace, key, text string) (*secret.Secret, error) {
+	keys := header.Keys()
+
+	if len(keys) > 1 && provider.NameOrDefault(header.Provider) != provider.KMS {
+		return nil, errors.Errorf("Multiple KMS keys are not supported by %s provider.", header.Provider)
+	}
+
+	s := &secret.Secret{
+		Key:     key,
+		DataKey: header.DataKey,
+		Region:  keys[0].Region,
+	}
+
+	for i, k := range keys {
+		c, err := cipherFor(header, k.Region)
+		if err != nil {
+			return nil, err
+		}
+
+		cipherText, err := c.EncryptBase64(k.Alias, namespace, key, text)
 		if err != nil {
-			return []string{}, errors.Wrap(err, "Failed to decrypt value.")
+			return nil, err
+		}
+
+		if i == 0 {
+			s.Value = provider.Format(header.Provider, cipherText)
+		} else {
+			s.Replicas = append(s.Replicas, &secret.Replica{
+				Region: k.Region,
+				Value:  provider.Format(header.Provider, cipherText),
+			})
 		}
+	}
+
+	return s, nil
+}
+
+var keyARNs = struct {
+	sync.Mutex
+	m map[string]string
+}{
+	m: map[string]string{},
+}
+
+// keyARN returns ARN of the key which the given key alias points to
+// ARNs are cached so that each alias is resolved only once.
+func keyARN(k *secret.KMSKeyConfig) (string, error) {
+	id := k.Region + "\x00" + k.Alias
+
+	keyARNs.Lock()
+	defer keyARNs.Unlock()
+
+	if arn, ok := keyARNs.m[id]; ok {
+		return arn, nil
+	}
+
+	kmsClient, err := aws.KMSForRegion(k.Region)
+	if err != nil {
+		return "", err
+	}
+
+	arn, err := kmsClient.KeyARN(k.Alias)
+	if err != nil {
+		return "", err
+	}
+
+	keyARNs.m[id] = arn
 
+	return arn, nil
+}
+
+// kmsKeyOf returns primary KMS key alias declared in the given secret file header
+func kmsKeyOf(header *secret.YAML) string {
+	return header.Keys()[0].Alias
+}
+
+// kmsKeyAliases returns comma-separated KMS key aliases declared in the given secret file header
+func kmsKeyAliases(header *secret.YAML) string {
+	aliases := []string{}
+
+	for _, k := range header.Keys() {
+		aliases = append(aliases, k.Alias)
+	}
+
+	return strings.Join(aliases, ",")
+}
+
+// verifyKMSKey returns keys of secrets which were not encrypted with KMS keys declared in the given secret file
+// Every cipher text of secret is verified against the corresponding key.
+// Secret files encrypted by providers which do not use KMS are not verified.
+func verifyKMSKey(namespace string, y *secret.YAML) ([]string, error) {
+	providerName := provider.NameOrDefault(y.Provider)
+
+	if providerName != provider.KMS && providerName != provider.Envelope {
+		return []string{}, nil
+	}
+
+	keys := y.Keys()
+
+	expected, err := expectedKeyARNs(keys)
+	if err != nil {
+		return []string{}, err
+	}
+
+	mismatched := []string{}
+
+	if providerName == provider.Envelope {
+		kmsClient, err := aws.KMSForRegion(keys[0].Region)
+		if err != nil {
+			return []string{}, err
+		}
+
+		actual, err := kmsClient.KeyARNOfDataKey(y.DataKey)
+		if err != nil {
+			return []string{}, errors.Wrap(err, "Failed to retrieve KMS key of data key.")
+		}
+
+		if actual != expected[0] {
+			for _, s := range y.Secrets {
+				mismatched = append(mismatched, s.Key)
+			}
+		}
+
+		return mismatched, nil
+	}
+
+	for _, s := range y.Secrets {
+		cipherTexts := cipherTextsOf(s)
+
+		if len(cipherTexts) != len(keys) {
+			mismatched = append(mismatched, s.Key)
+			continue
+		}
+
+		for i, cipherText := range cipherTexts {
+			actual, err := keyARNOfCipherText(namespace, s.Key, cipherText)
+			if err != nil {
+				return []string{}, errors.Wrapf(err, "Failed to retrieve KMS key of secret. key=%s", s.Key)
+			}
+
+			if actual != expected[i] || cipherText.Region != keys[i].Region {
+				mismatched = append(mismatched, s.Key)
+				break
+			}
+		}
+	}
+
+	return mismatched, nil
+}
+
+// expectedKeyARNs returns ARNs of KMS keys declared in the given secret file
+func expectedKeyARNs(keys []*secret.KMSKeyConfig) ([]string, error) {
+	arns := []string{}
+
+	for _, k := range keys {
+		arn, err := keyARN(k)
+		if err != nil {
+			return []string{}, errors.Wrapf(err, "Failed to resolve KMS key alias. keyAlias=%s", k.Alias)
+		}
+
+		arns = append(arns, arn)
+	}
+
+	return arns, nil
+}
+
+// stampKeyARNs records ARNs of KMS keys declared in the given secret file to its secrets
+// Secrets must be verified by verifyKMSKey beforehand. Recorded ARNs are stored with secrets,
+// so that valec pull can check KMS keys without decrypting values.
+func stampKeyARNs(y *secret.YAML) error {
+	providerName := provider.NameOrDefault(y.Provider)
+
+	if providerName != provider.KMS && providerName != provider.Envelope {
+		return nil
+	}
+
+	arns, err := expectedKeyARNs(y.Keys())
+	if err != nil {
+		return err
+	}
+
+	for _, s := range y.Secrets {
+		s.KeyARN = arns[0]
+
+		for i, r := range s.Replicas {
+			if i+1 < len(arns) {
+				r.KeyARN = arns[i+1]
+			}
+		}
+	}
+
+	return nil
+}
+
+// verifyStoredKMSKey returns keys of secrets whose recorded KMS keys differ from ones declared in the given secret file
+// Values are never decrypted, so secrets written without recorded KMS keys are returned as unknown.
+func verifyStoredKMSKey(y *secret.YAML) (mismatched, unknown []string, err error) {
+	mismatched, unknown = []string{}, []string{}
+	providerName := provider.NameOrDefault(y.Provider)
+
+	if providerName != provider.KMS && providerName != provider.Envelope {
+		return mismatched, unknown, nil
+	}
+
+	keys := y.Keys()
+
+	expected, err := expectedKeyARNs(keys)
+	if err != nil {
+		return []string{}, []string{}, err
+	}
+
+	for _, s := range y.Secrets {
+		cipherTexts := cipherTextsOf(s)
+
+		if len(cipherTexts) != len(keys) {
+			mismatched = append(mismatched, s.Key)
+			continue
+		}
+
+		for i, cipherText := range cipherTexts {
+			if cipherText.KeyARN == "" {
+				unknown = append(unknown, s.Key)
+				break
+			}
+
+			if cipherText.KeyARN != expected[i] || cipherText.Region != keys[i].Region {
+				mismatched = append(mismatched, s.Key)
+				break
+			}
+		}
+	}
+
+	return mismatched, unknown, nil
+}
+
+// keyARNOfCipherText returns ARN of the key which encrypted the given cipher text
+func keyARNOfCipherText(namespace, key string, cipherText *secret.Replica) (string, error) {
+	kmsClient, err := aws.KMSForRegion(cipherText.Region)
+	if err != nil {
+		return "", err
+	}
+
+	arn, err := kmsClient.KeyARNOfCipherText(namespace, key, cipherText.Value)
+	if err == nil || namespace == "" {
+		return arn, err
+	}
+
+	// Secrets which are not bound to namespace yet
+	if arn, err2 := kmsClient.KeyARNOfCipherText("", key, cipherText.Value); err2 == nil {
+		warnUnbound(namespace, key)
+		return arn, nil
+	}
+
+	return "", err
+}
+
+// storedSecret returns the stored secret with the given key, or nil if it does not exist
+func storedSecret(namespace, key string) (*secret.Secret, error) {
+	secrets, err := secretStore.ListSecrets(rootOpts.tableName, namespace)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to retrieve secrets. namespace=%s", namespace)
+	}
+
+	for _, s := range secrets {
+		if s.Key == key {
+			return s, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// namespaceOfFile returns namespace which secrets in the given file belong to
+// Namespace is derived from the file name unless it is given explicitly.
+func namespaceOfFile(filename, namespace string) (string, error) {
+	if namespace != "" {
+		return namespace, nil
+	}
+
+	return util.NamespaceFromPath(filename, filepath.Dir(filename))
+}
+
+func dumpAll(namespace string, secrets secret.Secrets, quote bool) ([]string, error) {
+	dotenv := []string{}
+
+	plainValues, err := decryptSecrets(namespace, secrets)
+	if err != nil {
+		return []string{}, errors.Wrap(err, "Failed to decrypt values.")
+	}
+
+	for i, secret := range secrets {
 		if quote {
-			dotenv = append(dotenv, fmt.Sprintf("%s=%q", secret.Key, plainValue))
+			dotenv = append(dotenv, fmt.Sprintf("%s=%q", secret.Key, plainValues[i]))
 		} else {
-			dotenv = append(dotenv, fmt.Sprintf("%s=%s", secret.Key, plainValue))
+			dotenv = append(dotenv, fmt.Sprintf("%s=%s", secret.Key, plainValues[i]))
 		}
 	}
 
 	return dotenv, nil
 }
 
-func dumpWithTemplate(secrets secret.Secrets, quote bool, dotenvTemplate string, override bool) ([]string, error) {
+func dumpWithTemplate(namespace string, secrets secret.Secrets, quote bool, dotenvTemplate string, override bool) ([]string, error) {
 	fp, err := os.Open(dotenvTemplate)
 	if err != nil {
 		return []string{}, errors.Wrapf(err, "Failed to open dotenv template. filename=%s", dotenvTemplate)
 	}
 	defer fp.Close()
 
-	secretMap := secrets.ListToMap()
+	secretMap := map[string]*secret.Secret{}
+	for _, secret := range secrets {
+		secretMap[secret.Key] = secret
+	}
+
 	sc := bufio.NewScanner(fp)
-	dotenv := []string{}
+	lines := []string{}
+
+	// Secrets referred from template are decrypted at once
+	used := secret.Secrets{}
+	usedKeys := map[string]bool{}
 
 	for sc.Scan() {
 		line := sc.Text()
+		lines = append(lines, line)
 
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		ss := strings.SplitN(line, "=", 2)
+		if len(ss) != 2 {
+			continue
+		}
+
+		key, value := ss[0], ss[1]
+
+		if override || value == "" {
+			if s, ok := secretMap[key]; ok && !usedKeys[key] {
+				used = append(used, s)
+				usedKeys[key] = true
+			}
+		}
+	}
+
+	plainValues, err := decryptSecrets(namespace, used)
+	if err != nil {
+		return []string{}, errors.Wrap(err, "Failed to decrypt values.")
+	}
+
+	plainValueMap := map[string]string{}
+	for i, s := range used {
+		plainValueMap[s.Key] = plainValues[i]
+	}
+
+	dotenv := []string{}
+
+	for _, line := range lines {
 		if strings.HasPrefix(line, "#") {
 			dotenv = append(dotenv, line)
 			continue
@@ -58,13 +666,7 @@ func dumpWithTemplate(secrets secret.Secrets, quote bool, dotenvTemplate string,
 		key, value := ss[0], ss[1]
 
 		if override || value == "" {
-			v, ok := secretMap[key]
-			if ok {
-				plainValue, err := aws.KMS.DecryptBase64(key, v)
-				if err != nil {
-					return []string{}, errors.Wrap(err, "Failed to decrypt value.")
-				}
-
+			if plainValue, ok := plainValueMap[key]; ok {
 				value = plainValue
 			}
 		}
@@ -0,0 +1,378 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+
+	awsapi "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	kmsapi "github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
+	stsapi "github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+	"github.com/dtan4/valec/aws"
+	"github.com/dtan4/valec/aws/kms"
+	"github.com/dtan4/valec/aws/sts"
+	"github.com/dtan4/valec/msg"
+	"github.com/dtan4/valec/provider"
+	"github.com/dtan4/valec/secret"
+	"github.com/dtan4/valec/store"
+)
+
+const (
+	testIdentity = "arn:aws:iam::123456789012:user/valec"
+	testTable    = "valec-test"
+)
+
+// fakeKMS emulates KMS API with encryption context
+// Cipher text holds key ARN, encryption context and plain text as JSON, so it can be inspected in tests.
+type fakeKMS struct {
+	kmsiface.KMSAPI
+
+	// keys maps key alias to key ARN
+	keys map[string]string
+	// nonce makes cipher texts differ every time like real KMS
+	nonce int
+	mu    sync.Mutex
+}
+
+type fakeCipherText struct {
+	KeyARN    string            `json:"key_arn"`
+	Context   map[string]string `json:"context"`
+	Plaintext []byte            `json:"plaintext"`
+	Nonce     int               `json:"nonce"`
+}
+
+func (f *fakeKMS) encrypt(keyID string, context map[string]*string, plaintext []byte) (*fakeCipherText, []byte, error) {
+	arn, ok := f.keys[keyID]
+	if !ok {
+		return nil, nil, awserr.New("NotFoundException", "Key is not found.", nil)
+	}
+
+	f.mu.Lock()
+	f.nonce++
+	nonce := f.nonce
+	f.mu.Unlock()
+
+	c := &fakeCipherText{
+		KeyARN:    arn,
+		Context:   awsapi.StringValueMap(context),
+		Plaintext: plaintext,
+		Nonce:     nonce,
+	}
+
+	blob, err := json.Marshal(c)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return c, blob, nil
+}
+
+func (f *fakeKMS) decrypt(blob []byte, context map[string]*string) (*fakeCipherText, error) {
+	var c fakeCipherText
+
+	if err := json.Unmarshal(blob, &c); err != nil {
+		return nil, awserr.New("InvalidCiphertextException", "Cipher text is invalid.", err)
+	}
+
+	if !reflect.DeepEqual(c.Context, awsapi.StringValueMap(context)) {
+		return nil, awserr.New("InvalidCiphertextException", "Encryption context does not match.", nil)
+	}
+
+	return &c, nil
+}
+
+func (f *fakeKMS) Decrypt(input *kmsapi.DecryptInput) (*kmsapi.DecryptOutput, error) {
+	c, err := f.decrypt(input.CiphertextBlob, input.EncryptionContext)
+	if err != nil {
+		return nil, err
+	}
+
+	return &kmsapi.DecryptOutput{
+		KeyId:     awsapi.String(c.KeyARN),
+		Plaintext: c.Plaintext,
+	}, nil
+}
+
+func (f *fakeKMS) DescribeKey(input *kmsapi.DescribeKeyInput) (*kmsapi.DescribeKeyOutput, error) {
+	arn, ok := f.keys[*input.KeyId]
+	if !ok {
+		return nil, awserr.New("NotFoundException", "Key is not found.", nil)
+	}
+
+	return &kmsapi.DescribeKeyOutput{
+		KeyMetadata: &kmsapi.KeyMetadata{
+			Arn: awsapi.String(arn),
+		},
+	}, nil
+}
+
+func (f *fakeKMS) Encrypt(input *kmsapi.EncryptInput) (*kmsapi.EncryptOutput, error) {
+	c, blob, err := f.encrypt(*input.KeyId, input.EncryptionContext, input.Plaintext)
+	if err != nil {
+		return nil, err
+	}
+
+	return &kmsapi.EncryptOutput{
+		CiphertextBlob: blob,
+		KeyId:          awsapi.String(c.KeyARN),
+	}, nil
+}
+
+func (f *fakeKMS) ReEncrypt(input *kmsapi.ReEncryptInput) (*kmsapi.ReEncryptOutput, error) {
+	src, err := f.decrypt(input.CiphertextBlob, input.SourceEncryptionContext)
+	if err != nil {
+		return nil, err
+	}
+
+	c, blob, err := f.encrypt(*input.DestinationKeyId, input.DestinationEncryptionContext, src.Plaintext)
+	if err != nil {
+		return nil, err
+	}
+
+	return &kmsapi.ReEncryptOutput{
+		CiphertextBlob: blob,
+		KeyId:          awsapi.String(c.KeyARN),
+	}, nil
+}
+
+// fakeSTS returns the fixed caller identity
+type fakeSTS struct {
+	stsiface.STSAPI
+}
+
+func (f *fakeSTS) GetCallerIdentity(input *stsapi.GetCallerIdentityInput) (*stsapi.GetCallerIdentityOutput, error) {
+	return &stsapi.GetCallerIdentityOutput{
+		Arn: awsapi.String(testIdentity),
+	}, nil
+}
+
+// fakeStore holds secrets in memory
+// Only methods used by sync are implemented.
+type fakeStore struct {
+	store.SecretStore
+
+	namespaces map[string]map[string]*secret.Secret
+	// history holds previous versions of secrets by namespace and key
+	history map[string]map[string][]*secret.Secret
+	// failures holds errors returned by Apply for each namespace
+	failures map[string]error
+}
+
+func (f *fakeStore) Apply(table, namespace string, puts, deletes, originals []*secret.Secret) error {
+	if err, ok := f.failures[namespace]; ok {
+		return err
+	}
+
+	if _, ok := f.namespaces[namespace]; !ok {
+		f.namespaces[namespace] = map[string]*secret.Secret{}
+	}
+
+	for _, s := range puts {
+		stored := *s
+		stored.Version++
+		f.namespaces[namespace][s.Key] = &stored
+	}
+
+	for _, s := range deletes {
+		delete(f.namespaces[namespace], s.Key)
+	}
+
+	return nil
+}
+
+func (f *fakeStore) History(table, namespace, key string) ([]*secret.Secret, error) {
+	return f.history[namespace][key], nil
+}
+
+func (f *fakeStore) Insert(table, namespace string, secrets []*secret.Secret) error {
+	return f.Apply(table, namespace, secrets, []*secret.Secret{}, []*secret.Secret{})
+}
+
+func (f *fakeStore) ListNamespaces(table string) ([]string, error) {
+	namespaces := []string{}
+
+	for namespace := range f.namespaces {
+		namespaces = append(namespaces, namespace)
+	}
+
+	sort.Strings(namespaces)
+
+	return namespaces, nil
+}
+
+func (f *fakeStore) ListSecrets(table, namespace string) ([]*secret.Secret, error) {
+	secrets := secret.Secrets{}
+
+	for _, s := range f.namespaces[namespace] {
+		stored := *s
+		secrets = append(secrets, &stored)
+	}
+
+	sort.Sort(secrets)
+
+	return secrets, nil
+}
+
+// setupTest replaces AWS API clients and secret store with fakes, and returns temporary secret directory
+func setupTest(t *testing.T) (string, *fakeStore) {
+	dir, err := ioutil.TempDir("", "valec-cmd")
+	if err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
+	}
+
+	aws.KMS = kms.NewClient(&fakeKMS{
+		keys: map[string]string{
+			"alias/valec":     "arn:aws:kms:ap-northeast-1:123456789012:key/1",
+			"alias/valec-new": "arn:aws:kms:ap-northeast-1:123456789012:key/2",
+		},
+	})
+	aws.STS = sts.NewClient(&fakeSTS{})
+
+	s := &fakeStore{
+		namespaces: map[string]map[string]*secret.Secret{},
+		history:    map[string]map[string][]*secret.Secret{},
+		failures:   map[string]error{},
+	}
+	secretStore = s
+
+	// Clients cached by previous tests must not be used
+	ciphers.m = map[string]provider.Cipher{}
+	keyARNs.m = map[string]string{}
+	unboundWarnings.m = map[string]bool{}
+
+	rootOpts.auditLog = ""
+	rootOpts.tableName = testTable
+	exitCode = exitCodeOK
+	syncOpts.allowUnbound = false
+	syncOpts.parallel = 1
+	stdout = ioutil.Discard
+	stderr = ioutil.Discard
+	msg.SetOutput(ioutil.Discard)
+
+	return dir, s
+}
+
+// writeSecretFile writes secret file of the given namespace with secrets encrypted by the given key
+// Secrets are bound to contextNamespace, or not bound to any namespace if it is empty.
+func writeSecretFile(t *testing.T, dir, namespace, contextNamespace, keyAlias string, values map[string]string) string {
+	y := &secret.YAML{
+		KMSKey: keyAlias,
+	}
+
+	keys := []string{}
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		cipherText, err := aws.KMS.EncryptBase64(keyAlias, contextNamespace, key, values[key])
+		if err != nil {
+			t.Fatalf("Error should not be raised. error: %s", err)
+		}
+
+		y.Secrets = append(y.Secrets, &secret.Secret{
+			Key:   key,
+			Value: cipherText,
+		})
+	}
+
+	filename := filepath.Join(dir, namespace+".yaml")
+
+	if err := y.Save(filename); err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
+	}
+
+	return filename
+}
+
+func TestInspectSecret(t *testing.T) {
+	dir, _ := setupTest(t)
+	defer os.RemoveAll(dir)
+
+	testcases := []struct {
+		contextNamespace string
+		expected         *secretState
+	}{
+		{
+			contextNamespace: "hoge",
+			expected: &secretState{
+				plainValue: "bar",
+				bound:      true,
+				keyARNs:    []string{"arn:aws:kms:ap-northeast-1:123456789012:key/1"},
+			},
+		},
+		{
+			contextNamespace: "",
+			expected: &secretState{
+				plainValue: "bar",
+				bound:      false,
+				keyARNs:    []string{"arn:aws:kms:ap-northeast-1:123456789012:key/1"},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		cipherText, err := aws.KMS.EncryptBase64("valec", tc.contextNamespace, "FOO", "bar")
+		if err != nil {
+			t.Fatalf("Error should not be raised. error: %s", err)
+		}
+
+		actual, err := inspectSecret("hoge", &secret.Secret{Key: "FOO", Value: cipherText})
+		if err != nil {
+			t.Errorf("Error should not be raised. error: %s", err)
+			continue
+		}
+
+		if !reflect.DeepEqual(actual, tc.expected) {
+			t.Errorf("State does not match. expected: %#v, actual: %#v", tc.expected, actual)
+		}
+	}
+
+	cipherText, err := aws.KMS.EncryptBase64("valec", "fuga", "FOO", "bar")
+	if err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
+	}
+
+	if _, err := inspectSecret("hoge", &secret.Secret{Key: "FOO", Value: cipherText}); err == nil {
+		t.Errorf("Error should be raised for secret bound to another namespace.")
+	}
+}
+
+func TestDecryptSecret_unbound(t *testing.T) {
+	dir, _ := setupTest(t)
+	defer os.RemoveAll(dir)
+
+	var buf bytes.Buffer
+	stderr = &buf
+
+	cipherText, err := aws.KMS.EncryptBase64("valec", "", "FOO", "bar")
+	if err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		actual, err := decryptSecret("hoge", &secret.Secret{Key: "FOO", Value: cipherText})
+		if err != nil {
+			t.Fatalf("Error should not be raised. error: %s", err)
+		}
+
+		if actual != "bar" {
+			t.Errorf("Value does not match. expected: %q, actual: %q", "bar", actual)
+		}
+	}
+
+	expected := "Warning: secret is not bound to namespace. Run valec migrate-context to bind it. namespace=hoge, key=FOO\n"
+	if buf.String() != expected {
+		t.Errorf("Warning should be shown once. expected: %q, actual: %q", expected, buf.String())
+	}
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/dtan4/valec/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// historyCmd represents the history command
+var historyCmd = &cobra.Command{
+	Use:   "history NAMESPACE KEY",
+	Short: "Show previous versions of secret",
+	Long: `Show previous versions of secret
+
+Every overwritten or deleted secret is kept in history. Versions are shown
+with fingerprints of their plain values, so that plain values are never shown.
+Previous version can be restored by valec rollback with its version or ID.`,
+	RunE: doHistory,
+}
+
+func doHistory(cmd *cobra.Command, args []string) error {
+	if len(args) != 2 {
+		return errors.New("Please specify both namespace and key.")
+	}
+	namespace, key := args[0], args[1]
+
+	versions, err := secretStore.History(rootOpts.tableName, namespace, key)
+	if err != nil {
+		return errors.Wrap(err, "Failed to retrieve history.")
+	}
+
+	current, err := storedSecret(namespace, key)
+	if err != nil {
+		return errors.Wrap(err, "Failed to retrieve secret.")
+	}
+
+	if current != nil {
+		versions = append(versions, current)
+	}
+
+	if len(versions) == 0 {
+		return errors.Errorf("No secret matched. namespace=%s, key=%s", namespace, key)
+	}
+
+	// Versions which cannot be decrypted are shown as unknown
+	fingerprints := make([]string, len(versions))
+
+	util.Parallel(len(versions), rootOpts.concurrency, func(i int) error {
+		fingerprints[i] = "unknown"
+
+		if plainValue, err := decryptSecret(namespace, versions[i]); err == nil {
+			fingerprints[i] = fingerprint(plainValue)
+		}
+
+		return nil
+	})
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tVERSION\tUPDATED AT\tFINGERPRINT")
+
+	for i, s := range versions {
+		id, mark := s.HistoryID, ""
+		if s == current {
+			id, mark = "-", " (current)"
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s%s\n", id, strconv.FormatInt(s.Version, 10), formatTime(s.UpdatedAt), fingerprints[i], mark)
+	}
+
+	w.Flush()
+
+	if current == nil {
+		fmt.Printf("%s was deleted. Run `valec rollback %s %s --to VERSION` to restore it.\n", key, namespace, key)
+	}
+
+	return nil
+}
+
+// formatTime formats the given time in local time zone, or returns "-" if it is unknown
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+
+	return t.Local().Format("2006-01-02 15:04:05 MST")
+}
+
+func init() {
+	RootCmd.AddCommand(historyCmd)
+}
@@ -57,6 +57,10 @@ func doInit(cmd *cobra.Command, args []string) error {
 		fmt.Printf("DynamoDB table %s successfully created!\n", rootOpts.tableName)
 	}
 
+	// UpdateTimeToLive API is not available in the AWS SDK which valec depends on
+	fmt.Printf("Please enable TTL on attribute expires_at of DynamoDB table %s manually, so that deleted secrets expire from trash:\n", rootOpts.tableName)
+	fmt.Printf("  aws dynamodb update-time-to-live --table-name %s --time-to-live-specification Enabled=true,AttributeName=expires_at\n", rootOpts.tableName)
+
 	return nil
 }
 
@@ -3,9 +3,9 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 	"text/tabwriter"
 
-	"github.com/dtan4/valec/aws"
 	"github.com/dtan4/valec/secret"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -21,6 +21,8 @@ To list secret keys stored in DynamoDB, specify namespace:
   $ valec list NAMESPACE
 To list secret values together:
   $ valec list NAMESPACE --show-values
+To list when, by whom and from which commit secrets were written:
+  $ valec list NAMESPACE --long
 To list secret keys stored in local file, specify file:
   $ valec list -f qa.yaml
 
@@ -29,23 +31,28 @@ Encrypted values are decrypted and printed as plain text.`,
 }
 
 var listOpts = struct {
+	long       bool
+	namespace  string
 	secretFile string
 	showValues bool
 }{}
 
 func doList(cmd *cobra.Command, args []string) error {
 	var (
-		secrets []*secret.Secret
-		err     error
+		namespace string
+		secrets   []*secret.Secret
+		err       error
 	)
 
 	if listOpts.secretFile == "" {
 		if len(args) != 1 {
 			return errors.New("Please specify namespace or secret file (-f FILE).")
 		}
-		namespace := args[0]
+		namespace = args[0]
 
-		secrets, err = aws.DynamoDB.ListSecrets(rootOpts.tableName, namespace)
+		recordAudit(namespace)
+
+		secrets, err = secretStore.ListSecrets(rootOpts.tableName, namespace)
 		if err != nil {
 			return errors.Wrapf(err, "Failed to load secrets from DynamoDB. namespace=%s", namespace)
 		}
@@ -54,6 +61,15 @@ func doList(cmd *cobra.Command, args []string) error {
 			return errors.Errorf("Namespace %s does not exist.", namespace)
 		}
 	} else {
+		if listOpts.long {
+			return errors.New("--long flag is available only for secrets in DynamoDB.")
+		}
+
+		namespace, err = namespaceOfFile(listOpts.secretFile, listOpts.namespace)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to get namespace. filename=%s", listOpts.secretFile)
+		}
+
 		_, secrets, err = secret.LoadFromYAML(listOpts.secretFile)
 		if err != nil {
 			return errors.Wrapf(err, "Failed to load secrets from file. filename=%s", listOpts.secretFile)
@@ -62,14 +78,28 @@ func doList(cmd *cobra.Command, args []string) error {
 
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
 
-	for _, secret := range secrets {
-		plainValue, err := aws.KMS.DecryptBase64(secret.Key, secret.Value)
-		if err != nil {
-			return errors.Wrapf(err, "Failed to decrypt value. key=%q, value=%q", secret.Key, secret.Value)
-		}
+	plainValues, err := decryptSecrets(namespace, secrets)
+	if err != nil {
+		return errors.Wrap(err, "Failed to decrypt values.")
+	}
 
+	if listOpts.long {
+		header := "KEY\tVERSION\tUPDATED AT\tUPDATED BY\tCOMMIT"
 		if listOpts.showValues {
-			fmt.Fprintf(w, "%s\t%s\n", secret.Key+":", plainValue)
+			header += "\tVALUE"
+		}
+		fmt.Fprintln(w, header)
+	}
+
+	for i, secret := range secrets {
+		if listOpts.long {
+			row := fmt.Sprintf("%s\t%d\t%s\t%s\t%s", secret.Key, secret.Version, formatTime(secret.UpdatedAt), orDash(secret.UpdatedBy), orDash(shortCommit(secret.Commit)))
+			if listOpts.showValues {
+				row += "\t" + plainValues[i]
+			}
+			fmt.Fprintln(w, row)
+		} else if listOpts.showValues {
+			fmt.Fprintf(w, "%s\t%s\n", secret.Key+":", plainValues[i])
 		} else {
 			fmt.Fprintln(w, secret.Key)
 		}
@@ -80,9 +110,36 @@ func doList(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// shortCommit abbreviates the given git commit like git log --oneline
+func shortCommit(commit string) string {
+	dirty := strings.HasSuffix(commit, "-dirty")
+	commit = strings.TrimSuffix(commit, "-dirty")
+
+	if len(commit) > 7 {
+		commit = commit[:7]
+	}
+
+	if dirty {
+		commit += "-dirty"
+	}
+
+	return commit
+}
+
+// orDash returns "-" for empty string
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+
+	return s
+}
+
 func init() {
 	RootCmd.AddCommand(listCmd)
 
 	listCmd.Flags().StringVarP(&listOpts.secretFile, "file", "f", "", "Secret file")
+	listCmd.Flags().BoolVarP(&listOpts.long, "long", "l", false, "Show version, updated time, updater and commit")
+	listCmd.Flags().StringVar(&listOpts.namespace, "namespace", "", "Namespace of secret file (default: derived from file name)")
 	listCmd.Flags().BoolVar(&listOpts.showValues, "show-values", false, "Show values")
 }
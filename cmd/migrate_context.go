@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/dtan4/valec/msg"
+	"github.com/dtan4/valec/secret"
+	"github.com/dtan4/valec/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// migrateContextCmd represents the migrate-context command
+var migrateContextCmd = &cobra.Command{
+	Use:   "migrate-context SECRETDIR",
+	Short: "Bind secrets in local files to their namespace",
+	Long: `Bind secrets in local files to their namespace
+
+Secrets encrypted by older valec are not bound to namespace, so they can be
+decrypted even if they are copied to another namespace. This command
+re-encrypts such secrets with namespace in encryption context and updates
+local files in place.`,
+	RunE: doMigrateContext,
+}
+
+var migrateContextOpts = struct {
+	dryRun bool
+}{}
+
+func doMigrateContext(cmd *cobra.Command, args []string) error {
+	if len(args) < 1 {
+		return errors.New("Please specify secret directory.")
+	}
+	dirname := args[0]
+
+	if rootOpts.noColor {
+		msg.DisableColor()
+	}
+
+	files, err := util.ListYAMLFiles(dirname)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to read directory. dirname=%s", dirname)
+	}
+
+	for _, file := range files {
+		namespace, err := util.NamespaceFromPath(file, dirname)
+		if err != nil {
+			return errors.Wrap(err, "Failed to get namespace.")
+		}
+
+		if err := migrateContextFile(file, namespace); err != nil {
+			return errors.Wrapf(err, "Failed to migrate file. filename=%s", file)
+		}
+	}
+
+	return nil
+}
+
+func migrateContextFile(filename, namespace string) error {
+	msg.Bold.Println(namespace)
+
+	y, err := secret.LoadYAML(filename)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to load secrets. filename=%s", filename)
+	}
+
+	migrated := 0
+
+	for _, s := range y.Secrets {
+		if _, err := decryptSecretInNamespace(namespace, s); err == nil {
+			continue
+		}
+
+		recordAudit(namespace, s.Key)
+
+		plainValue, err := decryptSecretInNamespace("", s)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to decrypt value. key=%s", s.Key)
+		}
+
+		msg.Yellow.Printf("  + %s\n", s.Key)
+		migrated++
+
+		if migrateContextOpts.dryRun {
+			continue
+		}
+
+		encrypted, err := encryptSecret(y, namespace, s.Key, plainValue)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to encrypt value. key=%s", s.Key)
+		}
+
+		s.Value, s.Replicas = encrypted.Value, encrypted.Replicas
+	}
+
+	if migrated == 0 {
+		fmt.Println("  All secrets are already bound to namespace.")
+		return nil
+	}
+
+	fmt.Printf("  %d secrets will be re-encrypted.\n", migrated)
+
+	if migrateContextOpts.dryRun {
+		return nil
+	}
+
+	if err := y.Save(filename); err != nil {
+		return errors.Wrapf(err, "Failed to update local secret file. filename=%s", filename)
+	}
+
+	fmt.Printf("  %d secrets were successfully re-encrypted.\n", migrated)
+
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(migrateContextCmd)
+
+	migrateContextCmd.Flags().BoolVar(&migrateContextOpts.dryRun, "dry-run", false, "Dry run")
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// migrateNamespacesCmd represents the migrate-namespaces command
+var migrateNamespacesCmd = &cobra.Command{
+	Use:   "migrate-namespaces",
+	Short: "Register existing namespaces to namespace registry",
+	Long: `Register existing namespaces to namespace registry
+
+Namespaces are listed from namespace registry instead of scanning whole
+DynamoDB table. Tables created by older valec do not have namespace
+registry, so this command scans the table once and registers all
+namespaces in it.`,
+	RunE: doMigrateNamespaces,
+}
+
+func doMigrateNamespaces(cmd *cobra.Command, args []string) error {
+	namespaces, err := secretStore.MigrateNamespaceRegistry(rootOpts.tableName)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to migrate namespace registry. table=%s", rootOpts.tableName)
+	}
+
+	for _, namespace := range namespaces {
+		fmt.Println(namespace)
+	}
+
+	fmt.Printf("%d namespaces were successfully registered.\n", len(namespaces))
+
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(migrateNamespacesCmd)
+}
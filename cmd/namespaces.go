@@ -3,7 +3,6 @@ package cmd
 import (
 	"fmt"
 
-	"github.com/dtan4/valec/aws"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
@@ -17,7 +16,7 @@ var namespacesCmd = &cobra.Command{
 }
 
 func doNamespaces(cmd *cobra.Command, args []string) error {
-	namespaces, err := aws.DynamoDB.ListNamespaces(rootOpts.tableName)
+	namespaces, err := secretStore.ListNamespaces(rootOpts.tableName)
 	if err != nil {
 		return errors.Wrap(err, "Failed to retrieve namespaces.")
 	}
@@ -0,0 +1,619 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/dtan4/valec/aws"
+	"github.com/dtan4/valec/msg"
+	"github.com/dtan4/valec/plan"
+	"github.com/dtan4/valec/secret"
+	"github.com/dtan4/valec/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// planCmd represents the plan command
+var planCmd = &cobra.Command{
+	Use:   "plan SECRETDIR [NAMESPACE...]",
+	Short: "Show changes required to synchronize secrets",
+	Long: `Show changes required to synchronize secrets
+
+If -o flag is given, changes are saved to plan file together with the
+state of DynamoDB table they were computed against. Saved plan can be
+applied later by valec apply.`,
+	RunE: doPlan,
+}
+
+var planOpts = struct {
+	allowUnbound bool
+	exclude      []string
+	force        bool
+	only         []string
+	output       string
+	protected    []string
+	prune        bool
+}{}
+
+// planOptions represents how to compute changes to synchronize
+type planOptions struct {
+	scope     plan.Scope
+	force     bool
+	prune     bool
+	protected []string
+	// parallel is the number of namespaces planned concurrently
+	parallel int
+	// allowUnbound allows writing secrets which are not bound to their namespace
+	allowUnbound bool
+}
+
+// namespaceError represents an error which occurred in one namespace
+type namespaceError struct {
+	namespace string
+	err       error
+}
+
+func doPlan(cmd *cobra.Command, args []string) error {
+	if len(args) < 1 {
+		return errors.New("Please specify secret directory.")
+	}
+	dirname := args[0]
+
+	if rootOpts.noColor {
+		msg.DisableColor()
+	}
+
+	p, failures, err := makePlan(dirname, planOptions{
+		scope: plan.Scope{
+			Namespaces: args[1:],
+			Only:       planOpts.only,
+			Exclude:    planOpts.exclude,
+		},
+		force:        planOpts.force,
+		prune:        planOpts.prune,
+		protected:    planOpts.protected,
+		parallel:     1,
+		allowUnbound: planOpts.allowUnbound,
+	})
+	if err != nil {
+		return errors.Wrap(err, "Failed to make plan.")
+	}
+
+	if err := runPlan(p, plan.NewReport(p, true), runOptions{dryRun: true}); err != nil {
+		return errors.Wrap(err, "Failed to show plan.")
+	}
+
+	// Plan without some namespaces must not be applied
+	if len(failures) > 0 {
+		return errors.Errorf("Failed to plan %d namespaces.", len(failures))
+	}
+
+	if planOpts.output == "" {
+		return nil
+	}
+
+	if err := p.Save(planOpts.output); err != nil {
+		return errors.Wrapf(err, "Failed to save plan file. filename=%s", planOpts.output)
+	}
+
+	fmt.Fprintf(stdout, "Plan was saved to %s. Run `valec apply %s` to apply it.\n", planOpts.output, planOpts.output)
+
+	return nil
+}
+
+// makePlan computes changes required to synchronize secret files in the given directory
+// Only namespaces in scope are synchronized. Namespaces without local files are deleted only if prune is enabled,
+// and protected namespaces are never deleted.
+// Namespaces which failed to be planned are returned separately and excluded from the plan.
+func makePlan(dirname string, opts planOptions) (*plan.Plan, []*namespaceError, error) {
+	scope := opts.scope
+
+	if err := scope.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	if err := plan.ValidatePatterns(opts.protected); err != nil {
+		return nil, nil, err
+	}
+
+	files, err := util.ListYAMLFiles(dirname)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "Failed to read directory. dirname=%s", dirname)
+	}
+
+	srcNamespaces, err := secretStore.ListNamespaces(rootOpts.tableName)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "Failed to retrieve namespaces.")
+	}
+
+	p := plan.New(rootOpts.tableName, scope, srcNamespaces)
+	p.Commit = util.GitCommit(dirname)
+	srcNamespaces = scope.Filter(srcNamespaces)
+	dstNamespaces, dstFiles := []string{}, []string{}
+
+	for _, file := range files {
+		namespace, err := util.NamespaceFromPath(file, dirname)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "Failed to get namespace.")
+		}
+
+		if err := util.ValidateNamespace(namespace); err != nil {
+			return nil, nil, errors.Wrapf(err, "Invalid secret file. filename=%s", file)
+		}
+
+		if !scope.Includes(namespace) {
+			continue
+		}
+
+		dstNamespaces = append(dstNamespaces, namespace)
+		dstFiles = append(dstFiles, file)
+	}
+
+	namespaces := make([]*plan.Namespace, len(dstFiles))
+	outputs := make([]bytes.Buffer, len(dstFiles))
+	errs := make([]error, len(dstFiles))
+
+	util.Parallel(len(dstFiles), opts.parallel, func(i int) error {
+		n, err := planFile(&outputs[i], dstFiles[i], dstNamespaces[i], opts)
+		if err != nil {
+			errs[i] = errors.Wrapf(err, "Failed to compare file. filename=%s", dstFiles[i])
+			return errs[i]
+		}
+
+		namespaces[i] = n
+
+		return nil
+	})
+
+	failures := []*namespaceError{}
+
+	for i, namespace := range dstNamespaces {
+		if errs[i] != nil {
+			if outputs[i].Len() == 0 {
+				msg.Bold.Println(namespace)
+			}
+			outputs[i].WriteTo(stdout)
+			msg.Red.Printf("  %s\n", errs[i])
+
+			failures = append(failures, &namespaceError{namespace: namespace, err: errs[i]})
+			continue
+		}
+
+		p.Namespaces = append(p.Namespaces, namespaces[i])
+	}
+
+	_, deleted := util.CompareStrings(srcNamespaces, dstNamespaces)
+
+	if !opts.prune {
+		p.UnprunedNamespaces = deleted
+		return p, failures, nil
+	}
+
+	for _, namespace := range deleted {
+		secrets, err := secretStore.ListSecrets(rootOpts.tableName, namespace)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "Failed to retrieve secrets. namespace=%s", namespace)
+		}
+
+		p.DeletedNamespaces = append(p.DeletedNamespaces, &plan.Namespace{
+			Name:         namespace,
+			RemoteDigest: plan.Digest(secrets),
+		})
+	}
+
+	p.Protect(opts.protected)
+
+	return p, failures, nil
+}
+
+// planFile computes changes required to synchronize the given secret file to the namespace
+// Messages are written to w so that namespaces can be planned concurrently.
+func planFile(w io.Writer, filename, namespace string, opts planOptions) (*plan.Namespace, error) {
+	y, err := secret.LoadYAML(filename)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to load secrets. filename=%s", filename)
+	}
+	srcSecrets := y.Secrets
+
+	mismatched, err := verifyKMSKey(namespace, y)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to verify KMS key. namespace=%s", namespace)
+	}
+
+	if len(mismatched) > 0 {
+		msg.Fprintf(w, msg.Bold, "%s\n", namespace)
+		for _, key := range mismatched {
+			msg.Fprintf(w, msg.Red, "  Secret value is not encrypted with key %s. key=%s\n", kmsKeyAliases(y), key)
+		}
+
+		return nil, errors.Errorf("Some secrets are not encrypted with key %s.", kmsKeyAliases(y))
+	}
+
+	if err := stampKeyARNs(y); err != nil {
+		return nil, errors.Wrapf(err, "Failed to record KMS key. namespace=%s", namespace)
+	}
+
+	dstSecrets, err := secretStore.ListSecrets(rootOpts.tableName, namespace)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to retrieve secrets. namespace=%s", namespace)
+	}
+
+	added, updated, deleted := srcSecrets.CompareList(dstSecrets)
+
+	// Secrets are overwritten only if they are not changed by someone else after they were read
+	dstMap := map[string]*secret.Secret{}
+	for _, s := range dstSecrets {
+		dstMap[s.Key] = s
+	}
+
+	for _, s := range updated {
+		s.Version = dstMap[s.Key].Version
+	}
+
+	updated, reencrypted, fingerprints, err := classifyUpdated(namespace, updated, dstSecrets)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to compare secrets. namespace=%s", namespace)
+	}
+
+	if opts.force {
+		updated, reencrypted = append(updated, reencrypted...), secret.Secrets{}
+	}
+
+	// Secrets not bound to namespace can be decrypted even if they are copied to another namespace
+	if !opts.allowUnbound {
+		unbound := unboundSecrets(namespace, append(append(secret.Secrets{}, added...), updated...))
+
+		if len(unbound) > 0 {
+			msg.Fprintf(w, msg.Bold, "%s\n", namespace)
+			for _, key := range unbound {
+				msg.Fprintf(w, msg.Red, "  Secret value is not bound to namespace. key=%s\n", key)
+			}
+
+			return nil, errors.New("Some secrets are not bound to namespace. Run valec migrate-context to bind them, or use --allow-unbound.")
+		}
+	}
+
+	originals := secret.Secrets{}
+	for _, s := range updated {
+		originals = append(originals, dstMap[s.Key])
+	}
+
+	return &plan.Namespace{
+		Name:         namespace,
+		RemoteDigest: plan.Digest(dstSecrets),
+		Added:        added,
+		Updated:      updated,
+		Deleted:      deleted,
+		Reencrypted:  reencrypted,
+		Fingerprints: fingerprints,
+		Originals:    originals,
+	}, nil
+}
+
+// classifyUpdated splits updated secrets into ones whose values were changed and ones which were only re-encrypted
+// Because KMS cipher texts are not deterministic, plain values of both sides are compared.
+// Secrets encrypted with another KMS key or bound to namespace newly are regarded as changed.
+// Fingerprints of plain values are returned for changed secrets so that plain values are never shown.
+func classifyUpdated(namespace string, updated, dstSecrets secret.Secrets) (changed, reencrypted secret.Secrets, fingerprints map[string]string, err error) {
+	dstMap := map[string]*secret.Secret{}
+	for _, s := range dstSecrets {
+		dstMap[s.Key] = s
+	}
+
+	olds := secret.Secrets{}
+	for _, s := range updated {
+		olds = append(olds, dstMap[s.Key])
+	}
+
+	locals := make([]*secretState, len(updated))
+
+	if err := util.Parallel(len(updated), rootOpts.concurrency, func(i int) error {
+		state, err := inspectSecret(namespace, updated[i])
+		if err != nil {
+			return errors.Wrapf(err, "Failed to decrypt value. key=%s", updated[i].Key)
+		}
+
+		locals[i] = state
+
+		return nil
+	}); err != nil {
+		return secret.Secrets{}, secret.Secrets{}, map[string]string{}, errors.Wrap(err, "Failed to decrypt local values.")
+	}
+
+	// Stored values which cannot be decrypted are regarded as changed
+	oldStates := make([]*secretState, len(olds))
+
+	util.Parallel(len(olds), rootOpts.concurrency, func(i int) error {
+		if state, err := inspectSecret(namespace, olds[i]); err == nil {
+			oldStates[i] = state
+		}
+
+		return nil
+	})
+
+	fingerprints = map[string]string{}
+
+	for i, s := range updated {
+		old, local := oldStates[i], locals[i]
+
+		if old != nil && old.plainValue == local.plainValue && old.sameEncryption(local) && sameKeyLayout(olds[i], s) {
+			reencrypted = append(reencrypted, s)
+			continue
+		}
+
+		oldFingerprint := "unknown"
+		if old != nil {
+			oldFingerprint = fingerprint(old.plainValue)
+		}
+
+		changed = append(changed, s)
+		fingerprints[s.Key] = fmt.Sprintf("%s -> %s", oldFingerprint, fingerprint(local.plainValue))
+	}
+
+	return changed, reencrypted, fingerprints, nil
+}
+
+// sameKeyLayout returns whether two secrets are encrypted with the same data key in the same regions
+// Secrets re-encrypted with another data key or replicated to other regions must be written.
+func sameKeyLayout(s, other *secret.Secret) bool {
+	if s.DataKey != other.DataKey || s.Region != other.Region || len(s.Replicas) != len(other.Replicas) {
+		return false
+	}
+
+	for i := range s.Replicas {
+		if s.Replicas[i].Region != other.Replicas[i].Region {
+			return false
+		}
+	}
+
+	return true
+}
+
+// runOptions represents how to run changes in plan
+type runOptions struct {
+	dryRun bool
+	// yes deletes namespaces without confirmation
+	yes bool
+	// interactive confirms deletion of namespaces in console, otherwise it is declined unless yes is true
+	interactive bool
+	// parallel is the number of namespaces synchronized concurrently
+	parallel int
+	// summary shows the result of each namespace at last
+	summary bool
+}
+
+// runPlan shows changes in the given plan, and applies them unless dryRun is true
+// Failure in one namespace does not stop the others. Results are recorded in the given report.
+func runPlan(p *plan.Plan, report *plan.Report, opts runOptions) error {
+	if !opts.dryRun && p.HasChanges() {
+		if err := stampPlan(p); err != nil {
+			return err
+		}
+	}
+
+	var mu sync.Mutex
+
+	util.Parallel(len(p.Namespaces), opts.parallel, func(i int) error {
+		n := p.Namespaces[i]
+
+		var buf bytes.Buffer
+		msg.Fprintf(&buf, msg.Bold, "%s\n", n.Name)
+
+		err := runNamespacePlan(&buf, n, opts.dryRun)
+		if err != nil {
+			msg.Fprintf(&buf, msg.Red, "  %s\n", err)
+			report.Namespaces[i].SetStatus(plan.StatusFailed, err)
+		} else if !opts.dryRun && n.HasChanges() {
+			report.Namespaces[i].SetStatus(plan.StatusApplied, nil)
+		}
+
+		// Messages of each namespace are shown together
+		mu.Lock()
+		buf.WriteTo(stdout)
+		mu.Unlock()
+
+		return err
+	})
+
+	if len(p.UnprunedNamespaces) > 0 {
+		fmt.Fprintf(stdout, "%d namespaces exist only in DynamoDB. Use --prune to delete them.\n", len(p.UnprunedNamespaces))
+		for _, namespace := range p.UnprunedNamespaces {
+			fmt.Fprintf(stdout, "  %s\n", namespace)
+		}
+	}
+
+	if len(p.ProtectedNamespaces) > 0 {
+		fmt.Fprintf(stdout, "%d namespaces are protected and will not be deleted.\n", len(p.ProtectedNamespaces))
+		for _, namespace := range p.ProtectedNamespaces {
+			fmt.Fprintf(stdout, "  %s\n", namespace)
+		}
+	}
+
+	deleteNamespaces(p, report, opts)
+
+	if opts.summary {
+		showSummary(report)
+	}
+
+	if failed := report.Results()[plan.ResultFailed]; failed > 0 {
+		return errors.Errorf("Failed to synchronize %d namespaces.", failed)
+	}
+
+	return nil
+}
+
+// stampPlan records who writes secrets in the given plan from which commit
+func stampPlan(p *plan.Plan) error {
+	updatedBy, err := aws.STS.CallerIdentity()
+	if err != nil {
+		return errors.Wrap(err, "Failed to retrieve caller identity.")
+	}
+
+	for _, n := range p.Namespaces {
+		for _, s := range append(append(secret.Secrets{}, n.Updated...), n.Added...) {
+			s.UpdatedBy, s.Commit = updatedBy, p.Commit
+		}
+	}
+
+	return nil
+}
+
+// deleteNamespaces deletes namespaces in the given plan after confirmation unless dryRun is true
+func deleteNamespaces(p *plan.Plan, report *plan.Report, opts runOptions) {
+	for _, n := range p.DeletedNamespaces {
+		msg.RedBold.Printf("- %s\n", n.Name)
+	}
+
+	if len(p.DeletedNamespaces) == 0 {
+		return
+	}
+
+	fmt.Fprintf(stdout, "%d namespaces will be deleted.\n", len(p.DeletedNamespaces))
+
+	if opts.dryRun {
+		return
+	}
+
+	if !opts.yes && !(opts.interactive && util.Confirm(fmt.Sprintf("Delete %d namespaces?", len(p.DeletedNamespaces)))) {
+		fmt.Fprintln(stdout, "Namespaces were not deleted.")
+		return
+	}
+
+	deleted := 0
+
+	for i, n := range p.DeletedNamespaces {
+		recordAudit(n.Name)
+
+		if err := secretStore.DeleteNamespace(rootOpts.tableName, n.Name); err != nil {
+			err = errors.Wrapf(err, "Failed to delete namespace. namespace=%s", n.Name)
+			msg.Red.Printf("  %s\n", err)
+			report.DeletedNamespaces[i].SetStatus(plan.StatusFailed, err)
+
+			continue
+		}
+
+		report.DeletedNamespaces[i].SetStatus(plan.StatusApplied, nil)
+		deleted++
+	}
+
+	fmt.Fprintf(stdout, "%d namespaces were successfully deleted.\n", deleted)
+}
+
+// showSummary shows whether each namespace was synchronized, failed or skipped
+func showSummary(report *plan.Report) {
+	if len(report.Namespaces)+len(report.DeletedNamespaces) == 0 {
+		return
+	}
+
+	fmt.Fprintln(stdout, "")
+
+	w := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tACTION\tRESULT")
+
+	for _, n := range report.Namespaces {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", n.Namespace, "sync", n.Result())
+	}
+
+	for _, n := range report.DeletedNamespaces {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", n.Namespace, "delete", n.Result())
+	}
+
+	w.Flush()
+
+	counts := report.Results()
+	fmt.Fprintf(stdout, "%d succeeded, %d failed, %d skipped.\n", counts[plan.ResultSucceeded], counts[plan.ResultFailed], counts[plan.ResultSkipped])
+}
+
+// runNamespacePlan shows changes in the given namespace, and applies them unless dryRun is true
+// Messages are written to w so that namespaces can be synchronized concurrently.
+func runNamespacePlan(w io.Writer, n *plan.Namespace, dryRun bool) error {
+	if len(n.Reencrypted) > 0 {
+		fmt.Fprintf(w, "  %d secrets are only re-encrypted and will be skipped. Use --force to update them.\n", len(n.Reencrypted))
+		for _, secret := range n.Reencrypted {
+			fmt.Fprintf(w, "    ~ %s\n", secret.Key)
+		}
+	}
+
+	if len(n.Deleted) > 0 {
+		fmt.Fprintf(w, "  %d secrets will be deleted.\n", len(n.Deleted))
+		for _, secret := range n.Deleted {
+			msg.Fprintf(w, msg.Red, "    - %s\n", secret.Key)
+		}
+	}
+
+	if len(n.Updated) > 0 {
+		fmt.Fprintf(w, "  %d secrets will be updated.\n", len(n.Updated))
+		for _, secret := range n.Updated {
+			if fingerprint, ok := n.Fingerprints[secret.Key]; ok {
+				msg.Fprintf(w, msg.Yellow, "    + %s (%s)\n", secret.Key, fingerprint)
+			} else {
+				msg.Fprintf(w, msg.Yellow, "    + %s\n", secret.Key)
+			}
+		}
+	}
+
+	if len(n.Added) > 0 {
+		fmt.Fprintf(w, "  %d secrets will be added.\n", len(n.Added))
+		for _, secret := range n.Added {
+			msg.Fprintf(w, msg.Green, "    + %s\n", secret.Key)
+		}
+	}
+
+	if dryRun || !n.HasChanges() {
+		return nil
+	}
+
+	puts := append(append(secret.Secrets{}, n.Updated...), n.Added...)
+
+	for _, s := range append(append(secret.Secrets{}, puts...), n.Deleted...) {
+		recordAudit(n.Name, s.Key)
+	}
+
+	if err := secretStore.Apply(rootOpts.tableName, n.Name, puts, n.Deleted, n.Originals); err != nil {
+		reportConflict(w, err)
+		return errors.Wrapf(err, "Failed to apply changes. namespace=%s", n.Name)
+	}
+
+	if len(n.Deleted) > 0 {
+		fmt.Fprintf(w, "  %d secrets were successfully deleted.\n", len(n.Deleted))
+	}
+
+	if len(n.Updated) > 0 {
+		fmt.Fprintf(w, "  %d secrets were successfully updated.\n", len(n.Updated))
+	}
+
+	if len(n.Added) > 0 {
+		fmt.Fprintf(w, "  %d secrets were successfully added.\n", len(n.Added))
+	}
+
+	return nil
+}
+
+// reportConflict shows secrets which were changed by someone else during synchronization
+func reportConflict(w io.Writer, err error) {
+	conflict, ok := errors.Cause(err).(*secret.ConflictError)
+	if !ok {
+		return
+	}
+
+	for _, key := range conflict.Keys {
+		msg.Fprintf(w, msg.Red, "    ! %s was changed by someone else.\n", key)
+	}
+
+	msg.Fprintf(w, msg.Red, "  %d secrets of %s namespace conflicted. Please pull the latest changes and run again.\n", len(conflict.Keys), conflict.Namespace)
+}
+
+func init() {
+	RootCmd.AddCommand(planCmd)
+
+	planCmd.Flags().BoolVar(&planOpts.allowUnbound, "allow-unbound", false, "Allow writing secrets which are not bound to namespace")
+	planCmd.Flags().StringSliceVar(&planOpts.exclude, "exclude", []string{}, "Glob patterns of namespaces to exclude")
+	planCmd.Flags().BoolVar(&planOpts.force, "force", false, "Update secrets even if only their cipher texts were changed")
+	planCmd.Flags().StringSliceVar(&planOpts.only, "only", []string{}, "Glob patterns of namespaces to include")
+	planCmd.Flags().StringSliceVar(&planOpts.protected, "protect", defaultProtectedNamespaces(), "Glob patterns of namespaces never to delete")
+	planCmd.Flags().BoolVar(&planOpts.prune, "prune", false, "Delete namespaces which do not have secret files")
+	planCmd.Flags().StringVarP(&planOpts.output, "output", "o", "", "Save plan to the given file")
+}
@@ -0,0 +1,217 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/dtan4/valec/msg"
+	"github.com/dtan4/valec/provider"
+	"github.com/dtan4/valec/secret"
+	"github.com/dtan4/valec/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// pullCmd represents the pull command
+var pullCmd = &cobra.Command{
+	Use:   "pull SECRETDIR [NAMESPACE...]",
+	Short: "Write secrets in DynamoDB to local files",
+	Long: `Write secrets in DynamoDB to local files
+
+Secrets in each namespace are written to <namespace>.yaml in SECRETDIR as
+they are encrypted, so that valec sync can synchronize them back. All
+namespaces are pulled if no namespace is given.
+
+Header of existing file is kept. For new file, provider is derived from
+encrypted values, and KMS key is taken from --key flag.`,
+	RunE: doPull,
+}
+
+var pullOpts = struct {
+	dryRun  bool
+	kmsKeys []string
+}{}
+
+func doPull(cmd *cobra.Command, args []string) error {
+	if len(args) < 1 {
+		return errors.New("Please specify secret directory.")
+	}
+	dirname := args[0]
+
+	if rootOpts.noColor {
+		msg.DisableColor()
+	}
+
+	namespaces := args[1:]
+
+	if len(namespaces) == 0 {
+		ns, err := secretStore.ListNamespaces(rootOpts.tableName)
+		if err != nil {
+			return errors.Wrap(err, "Failed to retrieve namespaces.")
+		}
+
+		namespaces = ns
+	}
+
+	var kmsKeys []*secret.KMSKeyConfig
+
+	if cmd.Flags().Changed("key") {
+		k, err := parseKMSKeys(pullOpts.kmsKeys)
+		if err != nil {
+			return errors.Wrap(err, "Failed to parse KMS keys.")
+		}
+
+		kmsKeys = k
+	}
+
+	for _, namespace := range namespaces {
+		if err := pullNamespace(dirname, namespace, kmsKeys); err != nil {
+			return errors.Wrapf(err, "Failed to pull namespace. namespace=%s", namespace)
+		}
+	}
+
+	return nil
+}
+
+func pullNamespace(dirname, namespace string, kmsKeys []*secret.KMSKeyConfig) error {
+	msg.Bold.Println(namespace)
+
+	secrets, err := secretStore.ListSecrets(rootOpts.tableName, namespace)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to retrieve secrets. namespace=%s", namespace)
+	}
+
+	if len(secrets) == 0 {
+		fmt.Println("  No secret is stored.")
+		return nil
+	}
+
+	remote := secret.Secrets(secrets)
+	sort.Sort(remote)
+
+	for _, s := range remote {
+		recordAudit(namespace, s.Key)
+	}
+
+	// Secret file holds only one data key of envelope provider
+	dataKey := remote[0].DataKey
+
+	for _, s := range remote {
+		if s.DataKey != dataKey {
+			return errors.Errorf("Secrets are sealed with different data keys, which one secret file cannot hold. key=%s", s.Key)
+		}
+	}
+
+	filename, err := util.PathFromNamespace(namespace, dirname)
+	if err != nil {
+		return errors.Wrap(err, "Failed to get secret file path.")
+	}
+	exists := util.IsExist(filename)
+
+	var header *secret.YAML
+	local := secret.Secrets{}
+
+	if exists {
+		y, err := secret.LoadYAML(filename)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to load secrets. filename=%s", filename)
+		}
+
+		header, local = y, y.Secrets
+	} else {
+		header = pulledHeader(remote, kmsKeys)
+	}
+
+	y := &secret.YAML{
+		Provider:   header.Provider,
+		KMSKey:     header.KMSKey,
+		KMSKeys:    header.KMSKeys,
+		DataKey:    dataKey,
+		Recipients: header.Recipients,
+		Secrets:    remote,
+	}
+
+	added, updated, deleted := remote.CompareList(local)
+
+	for _, s := range deleted {
+		msg.Red.Printf("  - %s\n", s.Key)
+	}
+
+	for _, s := range updated {
+		msg.Yellow.Printf("  + %s\n", s.Key)
+	}
+
+	for _, s := range added {
+		msg.Green.Printf("  + %s\n", s.Key)
+	}
+
+	if exists && len(added)+len(updated)+len(deleted) == 0 && y.DataKey == header.DataKey {
+		fmt.Println("  Local file is up to date.")
+		return nil
+	}
+
+	if provider.NameOrDefault(y.Provider) == provider.Age && len(y.Recipients) == 0 {
+		msg.Yellow.Println("  Recipients of age provider are unknown. Please add them to the file.")
+	}
+
+	mismatched, unknown, err := verifyStoredKMSKey(y)
+	if err != nil {
+		return errors.Wrap(err, "Failed to verify KMS key.")
+	}
+
+	for _, key := range mismatched {
+		msg.Yellow.Printf("  Secret value is not encrypted with key %s. Please fix KMS key in the file. key=%s\n", kmsKeyAliases(y), key)
+	}
+
+	if len(unknown) > 0 {
+		msg.Yellow.Printf("  KMS key of %d secrets was not recorded. Run valec validate to verify the file.\n", len(unknown))
+	}
+
+	if pullOpts.dryRun {
+		fmt.Printf("  %s will be written.\n", filename)
+		return nil
+	}
+
+	if err := y.Save(filename); err != nil {
+		return errors.Wrapf(err, "Failed to save secrets. filename=%s", filename)
+	}
+
+	fmt.Printf("  %s was successfully written.\n", filename)
+
+	return nil
+}
+
+// pulledHeader returns secret file header for the given secrets pulled from DynamoDB
+// KMS key aliases are not stored in DynamoDB, so default alias is used in every region unless given.
+func pulledHeader(secrets secret.Secrets, kmsKeys []*secret.KMSKeyConfig) *secret.YAML {
+	providerName, _ := provider.Parse(secrets[0].Value)
+
+	if kmsKeys == nil {
+		kmsKeys = []*secret.KMSKeyConfig{
+			&secret.KMSKeyConfig{
+				Alias:  secret.DefaultKMSKey,
+				Region: secrets[0].Region,
+			},
+		}
+
+		for _, r := range secrets[0].Replicas {
+			kmsKeys = append(kmsKeys, &secret.KMSKeyConfig{
+				Alias:  secret.DefaultKMSKey,
+				Region: r.Region,
+			})
+		}
+	}
+
+	if providerName == provider.DefaultProvider {
+		providerName = ""
+	}
+
+	return newHeader(providerName, kmsKeys, []string{})
+}
+
+func init() {
+	RootCmd.AddCommand(pullCmd)
+
+	pullCmd.Flags().BoolVar(&pullOpts.dryRun, "dry-run", false, "Dry run")
+	pullCmd.Flags().StringSliceVarP(&pullOpts.kmsKeys, "key", "k", []string{secret.DefaultKMSKey}, "KMS key alias of new files (ALIAS or ALIAS@REGION, can be specified multiple times)")
+}
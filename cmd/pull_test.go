@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/dtan4/valec/secret"
+	"github.com/dtan4/valec/util"
+)
+
+func TestPullNamespace_dataKeys(t *testing.T) {
+	dir, s := setupTest(t)
+	defer os.RemoveAll(dir)
+
+	s.namespaces["hoge"] = map[string]*secret.Secret{
+		"BAR": &secret.Secret{Key: "BAR", Value: "envelope:bar", DataKey: "datakey1"},
+		"FOO": &secret.Secret{Key: "FOO", Value: "envelope:foo", DataKey: "datakey2"},
+	}
+
+	if err := pullNamespace(dir, "hoge", nil); err == nil {
+		t.Errorf("Error should be raised for secrets sealed with different data keys.")
+	}
+
+	if util.IsExist(filepath.Join(dir, "hoge.yaml")) {
+		t.Errorf("Secret file should not be written.")
+	}
+}
+
+func TestVerifyStoredKMSKey(t *testing.T) {
+	dir, _ := setupTest(t)
+	defer os.RemoveAll(dir)
+
+	// Values are never decrypted, so they do not need to be valid cipher texts
+	y := &secret.YAML{
+		KMSKey: "valec",
+		Secrets: secret.Secrets{
+			&secret.Secret{Key: "BAR", Value: "invalid", KeyARN: "arn:aws:kms:ap-northeast-1:123456789012:key/2"},
+			&secret.Secret{Key: "BAZ", Value: "invalid"},
+			&secret.Secret{Key: "FOO", Value: "invalid", KeyARN: "arn:aws:kms:ap-northeast-1:123456789012:key/1"},
+		},
+	}
+
+	mismatched, unknown, err := verifyStoredKMSKey(y)
+	if err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
+	}
+
+	if !reflect.DeepEqual(mismatched, []string{"BAR"}) {
+		t.Errorf("Mismatched keys do not match. expected: %q, actual: %q", []string{"BAR"}, mismatched)
+	}
+
+	if !reflect.DeepEqual(unknown, []string{"BAZ"}) {
+		t.Errorf("Unknown keys do not match. expected: %q, actual: %q", []string{"BAZ"}, unknown)
+	}
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dtan4/valec/aws"
+	"github.com/dtan4/valec/secret"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// rollbackCmd represents the rollback command
+var rollbackCmd = &cobra.Command{
+	Use:   "rollback NAMESPACE KEY --to VERSION",
+	Short: "Restore previous version of secret",
+	Long: `Restore previous version of secret
+
+The cipher text of the given version in history is written as the new version,
+so that rollback itself can be rolled back. Version numbers restart after
+deletion, so the same version may appear more than once in history. Such
+versions can be specified by --id flag with IDs shown by valec history.
+
+Local secret file still holds the newer value after rollback. Please update it
+by valec pull, otherwise the next valec sync overwrites the restored value.`,
+	RunE: doRollback,
+}
+
+var rollbackOpts = struct {
+	id string
+	to int64
+}{}
+
+func doRollback(cmd *cobra.Command, args []string) error {
+	if len(args) != 2 {
+		return errors.New("Please specify both namespace and key.")
+	}
+	namespace, key := args[0], args[1]
+
+	byVersion := cmd.Flags().Changed("to")
+
+	if byVersion == (rollbackOpts.id != "") {
+		return errors.New("Please specify version by either --to or --id flag.")
+	}
+
+	versions, err := secretStore.History(rootOpts.tableName, namespace, key)
+	if err != nil {
+		return errors.Wrap(err, "Failed to retrieve history.")
+	}
+
+	var target *secret.Secret
+	ids := []string{}
+
+	for _, s := range versions {
+		if byVersion && s.Version == rollbackOpts.to || !byVersion && s.HistoryID == rollbackOpts.id {
+			target = s
+			ids = append(ids, s.HistoryID)
+		}
+	}
+
+	if target == nil {
+		if byVersion {
+			return errors.Errorf("Version is not found in history. namespace=%s, key=%s, version=%d", namespace, key, rollbackOpts.to)
+		}
+
+		return errors.Errorf("Version is not found in history. namespace=%s, key=%s, id=%s", namespace, key, rollbackOpts.id)
+	}
+
+	if len(ids) > 1 {
+		return errors.Errorf("Version appears more than once in history. Please specify one of them by --id flag. version=%d, ids=%s", rollbackOpts.to, strings.Join(ids, ","))
+	}
+
+	version := fmt.Sprintf("version %d (ID %s)", target.Version, target.HistoryID)
+
+	current, err := storedSecret(namespace, key)
+	if err != nil {
+		return errors.Wrap(err, "Failed to retrieve secret.")
+	}
+
+	restored := &secret.Secret{
+		Key:      key,
+		Value:    target.Value,
+		Replicas: target.Replicas,
+		DataKey:  target.DataKey,
+		Region:   target.Region,
+		KeyARN:   target.KeyARN,
+	}
+
+	if current != nil {
+		if current.Value == target.Value {
+			fmt.Printf("%s is already the same as %s.\n", key, version)
+			return nil
+		}
+
+		restored.Version = current.Version
+	}
+
+	restored.UpdatedBy, err = aws.STS.CallerIdentity()
+	if err != nil {
+		return errors.Wrap(err, "Failed to retrieve caller identity.")
+	}
+
+	recordAudit(namespace, key)
+
+	if err := secretStore.Insert(rootOpts.tableName, namespace, []*secret.Secret{restored}); err != nil {
+		reportConflict(stdout, err)
+		return errors.Wrap(err, "Failed to restore secret.")
+	}
+
+	fmt.Printf("%s was successfully rolled back to %s.\n", key, version)
+
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(rollbackCmd)
+
+	rollbackCmd.Flags().StringVar(&rollbackOpts.id, "id", "", "ID of version to restore, shown by valec history")
+	rollbackCmd.Flags().Int64Var(&rollbackOpts.to, "to", 0, "Version to restore")
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"os"
+	"testing"
+
+	"github.com/dtan4/valec/secret"
+)
+
+func TestDoRollback(t *testing.T) {
+	dir, s := setupTest(t)
+	defer os.RemoveAll(dir)
+
+	// Versions restart after deletion, so the same version appears twice
+	s.history["hoge"] = map[string][]*secret.Secret{
+		"FOO": []*secret.Secret{
+			&secret.Secret{
+				Key:       "FOO",
+				Value:     "deleted",
+				Version:   1,
+				HistoryID: "01483326245000000000",
+			},
+			&secret.Secret{
+				Key:       "FOO",
+				Value:     "overwritten",
+				Version:   1,
+				HistoryID: "01483412645000000000",
+			},
+			&secret.Secret{
+				Key:       "FOO",
+				Value:     "previous",
+				Version:   2,
+				HistoryID: "01483499045000000000",
+			},
+		},
+	}
+	s.namespaces["hoge"] = map[string]*secret.Secret{
+		"FOO": &secret.Secret{
+			Key:     "FOO",
+			Value:   "current",
+			Version: 3,
+		},
+	}
+
+	defer func() {
+		rollbackOpts.id, rollbackOpts.to = "", 0
+		rollbackCmd.Flags().Lookup("to").Changed = false
+	}()
+
+	if err := doRollback(rollbackCmd, []string{"hoge", "FOO"}); err == nil {
+		t.Errorf("Error should be raised without version.")
+	}
+
+	rollbackCmd.Flags().Set("to", "2")
+
+	if err := doRollback(rollbackCmd, []string{"hoge", "FOO"}); err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
+	}
+
+	if actual := s.namespaces["hoge"]["FOO"]; actual.Value != "previous" || actual.Version != 4 {
+		t.Errorf("Version 2 should be restored as version 4. actual: %q, version: %d", actual.Value, actual.Version)
+	}
+
+	rollbackCmd.Flags().Set("to", "1")
+
+	if err := doRollback(rollbackCmd, []string{"hoge", "FOO"}); err == nil {
+		t.Errorf("Error should be raised for ambiguous version.")
+	}
+
+	rollbackCmd.Flags().Lookup("to").Changed = false
+	rollbackOpts.id = "01483326245000000000"
+
+	if err := doRollback(rollbackCmd, []string{"hoge", "FOO"}); err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
+	}
+
+	if actual := s.namespaces["hoge"]["FOO"]; actual.Value != "deleted" || actual.Version != 5 {
+		t.Errorf("Version with the given ID should be restored as version 5. actual: %q, version: %d", actual.Value, actual.Version)
+	}
+
+	rollbackOpts.id = "1"
+
+	if err := doRollback(rollbackCmd, []string{"hoge", "FOO"}); err == nil {
+		t.Errorf("Error should be raised for unknown ID.")
+	}
+}
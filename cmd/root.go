@@ -2,16 +2,28 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/dtan4/valec/aws"
+	"github.com/dtan4/valec/aws/dynamodb"
+	"github.com/dtan4/valec/store"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
 const (
-	defaultKeyAlias  = "valec"
-	defaultTableName = "valec"
+	defaultConcurrency = 10
+	defaultKeyAlias    = "valec"
+	defaultTableName   = "valec"
+)
+
+const (
+	exitCodeOK      = 0
+	exitCodeFailed  = 1
+	exitCodePending = 2
 )
 
 // RootCmd represents the base command when called without any subcommands
@@ -23,46 +35,119 @@ var RootCmd = &cobra.Command{
 	Long: `Valec is a CLI tool to handle application secrets securely using AWS DynamoDB and KMS.
 Valec enables you to manage application secrets in your favorite VCS.`,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := openAuditLog(cmd); err != nil {
+			return errors.Wrap(err, "Failed to open audit log.")
+		}
+
 		if err := aws.Initialize(rootOpts.region); err != nil {
 			return errors.Wrap(err, "Failed to initialize AWS API clients.")
 		}
 
+		if err := aws.DynamoDB.SetMaxRetries(rootOpts.maxRetries); err != nil {
+			return errors.Wrap(err, "Invalid --max-retries flag.")
+		}
+
+		s, err := store.New(rootOpts.backend)
+		if err != nil {
+			return errors.Wrap(err, "Failed to initialize secret store.")
+		}
+		secretStore = s
+
 		return nil
 	},
+	PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
+		return closeAuditLog(nil)
+	},
 	// Uncomment the following line if your bare application
 	// has an action associated with it:
 	//	Run: func(cmd *cobra.Command, args []string) { },
 }
 
 var rootOpts = struct {
-	debug     bool
-	noColor   bool
-	tableName string
-	region    string
+	auditLog     string
+	backend      string
+	concurrency  int
+	debug        bool
+	identityFile string
+	maxRetries   int
+	noColor      bool
+	tableName    string
+	region       string
 }{}
 
+var secretStore store.SecretStore
+
+// stdout is where human-readable messages of commands are written
+var stdout io.Writer = os.Stdout
+
+// stderr is where warnings of commands are written
+var stderr io.Writer = os.Stderr
+
+// exitCode is the exit status of the command which finished without error
+var exitCode = exitCodeOK
+
 // Execute adds all child commands to the root command sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
 	if err := RootCmd.Execute(); err != nil {
+		if err2 := closeAuditLog(err); err2 != nil {
+			fmt.Fprintln(os.Stderr, err2)
+		}
+
 		if rootOpts.debug {
-			fmt.Printf("%+v\n", err)
+			fmt.Fprintf(os.Stderr, "%+v\n", err)
 		} else {
-			fmt.Println(err)
+			fmt.Fprintln(os.Stderr, err)
 		}
-		os.Exit(-1)
+		os.Exit(exitCodeFailed)
 	}
+
+	os.Exit(exitCode)
 }
 
 func init() {
 	cobra.OnInitialize(initConfig)
 
+	RootCmd.PersistentFlags().StringVar(&rootOpts.auditLog, "audit-log", defaultAuditLog(), "File path or syslog address to append audit log to")
+	RootCmd.PersistentFlags().StringVar(&rootOpts.backend, "backend", store.DefaultBackend, "Secret storage backend")
+	RootCmd.PersistentFlags().IntVar(&rootOpts.concurrency, "concurrency", defaultConcurrency, "Number of secrets decrypted concurrently")
 	RootCmd.PersistentFlags().BoolVar(&rootOpts.debug, "debug", false, "Debug mode")
+	RootCmd.PersistentFlags().StringVar(&rootOpts.identityFile, "identity-file", defaultIdentityFile(), "Identity file for age provider")
+	RootCmd.PersistentFlags().IntVar(&rootOpts.maxRetries, "max-retries", dynamodb.DefaultMaxRetries, "Max retries for items which DynamoDB left unprocessed")
 	RootCmd.PersistentFlags().BoolVar(&rootOpts.noColor, "no-color", false, "Disable colorized output")
 	RootCmd.PersistentFlags().StringVar(&rootOpts.tableName, "table-name", defaultTableName, "DynamoDB table name")
 	RootCmd.PersistentFlags().StringVar(&rootOpts.region, "region", "", "AWS region")
 }
 
+// defaultIdentityFile returns the path of identity file for age provider
+// VALEC_IDENTITY_FILE environment variable overrides the default location.
+func defaultIdentityFile() string {
+	if filename := os.Getenv("VALEC_IDENTITY_FILE"); filename != "" {
+		return filename
+	}
+
+	home := os.Getenv("HOME")
+	if home == "" {
+		home = os.Getenv("USERPROFILE")
+	}
+
+	return filepath.Join(home, ".valec", "identity.txt")
+}
+
+// defaultProtectedNamespaces returns glob patterns of namespaces which sync never deletes
+// They are read from comma-separated VALEC_PROTECTED_NAMESPACES environment variable.
+func defaultProtectedNamespaces() []string {
+	patterns := []string{}
+
+	for _, pattern := range strings.Split(os.Getenv("VALEC_PROTECTED_NAMESPACES"), ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+	}
+
+	return patterns
+}
+
 // initConfig reads in config file and ENV variables if set.
 func initConfig() {
 }
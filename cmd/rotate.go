@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/dtan4/valec/aws"
+	"github.com/dtan4/valec/msg"
+	"github.com/dtan4/valec/provider"
+	"github.com/dtan4/valec/secret"
+	"github.com/dtan4/valec/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// rotateCmd represents the rotate command
+var rotateCmd = &cobra.Command{
+	Use:   "rotate FILE|SECRETDIR --to-key KEY",
+	Short: "Re-encrypt secrets in local files with another KMS key",
+	Long: `Re-encrypt secrets in local files with another KMS key
+
+Secrets are re-encrypted by KMS ReEncrypt API, so plain values never leave KMS.
+kms_key field (or the first key of kms_keys) of each file is updated to the given key alias.
+
+Rotate single file:
+  $ valec rotate secrets/qa.yaml --to-key valec-qa
+Rotate all files in directory:
+  $ valec rotate secrets --to-key valec-qa`,
+	RunE: doRotate,
+}
+
+var rotateOpts = struct {
+	namespace string
+	toKey     string
+}{}
+
+func doRotate(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return errors.New("Please specify secret file or directory.")
+	}
+	target := args[0]
+
+	if rotateOpts.toKey == "" {
+		return errors.New("Please specify KMS key alias (--to-key KEY).")
+	}
+
+	if rootOpts.noColor {
+		msg.DisableColor()
+	}
+
+	fi, err := os.Stat(target)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to open secret file or directory. path=%s", target)
+	}
+
+	if !fi.IsDir() {
+		namespace, err := namespaceOfFile(target, rotateOpts.namespace)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to get namespace. filename=%s", target)
+		}
+
+		if err := rotateFile(target, namespace, rotateOpts.toKey); err != nil {
+			return errors.Wrapf(err, "Failed to rotate file. filename=%s", target)
+		}
+
+		return nil
+	}
+
+	files, err := util.ListYAMLFiles(target)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to read directory. dirname=%s", target)
+	}
+
+	for _, file := range files {
+		namespace, err := util.NamespaceFromPath(file, target)
+		if err != nil {
+			return errors.Wrap(err, "Failed to get namespace.")
+		}
+
+		if err := rotateFile(file, namespace, rotateOpts.toKey); err != nil {
+			return errors.Wrapf(err, "Failed to rotate file. filename=%s", file)
+		}
+	}
+
+	return nil
+}
+
+func rotateFile(filename, namespace, toKey string) error {
+	msg.Bold.Println(filename)
+
+	y, err := secret.LoadYAML(filename)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to load secrets. filename=%s", filename)
+	}
+
+	providerName := provider.NameOrDefault(y.Provider)
+
+	if providerName != provider.KMS && providerName != provider.Envelope {
+		fmt.Printf("  Provider %s does not use KMS key. Skipped.\n", providerName)
+		return nil
+	}
+
+	fromKey := kmsKeyOf(y)
+
+	if fromKey == toKey {
+		fmt.Printf("  Secrets are already encrypted with key %s.\n", toKey)
+		return nil
+	}
+
+	switch providerName {
+	case provider.KMS:
+		for _, s := range y.Secrets {
+			cipherText, err := reEncryptValue(namespace, s, toKey)
+			if err != nil {
+				return errors.Wrapf(err, "Failed to re-encrypt value. key=%s", s.Key)
+			}
+
+			s.Value = cipherText
+		}
+
+		fmt.Printf("  %d secrets were re-encrypted. %s -> %s\n", len(y.Secrets), fromKey, toKey)
+	case provider.Envelope:
+		kmsClient, err := aws.KMSForRegion(y.Keys()[0].Region)
+		if err != nil {
+			return err
+		}
+
+		dataKey, err := kmsClient.ReEncryptDataKey(toKey, y.DataKey)
+		if err != nil {
+			return errors.Wrap(err, "Failed to re-encrypt data key.")
+		}
+
+		y.DataKey = dataKey
+
+		fmt.Printf("  Data key was re-encrypted. %s -> %s\n", fromKey, toKey)
+	}
+
+	if len(y.KMSKeys) > 0 {
+		y.KMSKeys[0].Alias = toKey
+	} else {
+		y.KMSKey = toKey
+	}
+
+	if err := y.Save(filename); err != nil {
+		return errors.Wrapf(err, "Failed to update local secret file. filename=%s", filename)
+	}
+
+	return nil
+}
+
+// reEncryptValue re-encrypts the given secret with the given key without changing its encryption context
+// Only the cipher text encrypted with the primary key is re-encrypted.
+func reEncryptValue(namespace string, s *secret.Secret, toKey string) (string, error) {
+	kmsClient, err := aws.KMSForRegion(s.Region)
+	if err != nil {
+		return "", err
+	}
+
+	cipherText, err := kmsClient.ReEncryptBase64(toKey, namespace, s.Key, s.Value)
+	if err == nil || namespace == "" {
+		return cipherText, err
+	}
+
+	// Secrets which are not bound to namespace yet
+	if cipherText, err2 := kmsClient.ReEncryptBase64(toKey, "", s.Key, s.Value); err2 == nil {
+		warnUnbound(namespace, s.Key)
+		return cipherText, nil
+	}
+
+	return "", err
+}
+
+func init() {
+	RootCmd.AddCommand(rotateCmd)
+
+	rotateCmd.Flags().StringVar(&rotateOpts.namespace, "namespace", "", "Namespace of secret file (default: derived from file name)")
+	rotateCmd.Flags().StringVar(&rotateOpts.toKey, "to-key", "", "KMS key alias to re-encrypt secrets with")
+}
@@ -1,25 +1,48 @@
 package cmd
 
 import (
-	"fmt"
+	"io/ioutil"
+	"os"
 
-	"github.com/dtan4/valec/aws"
 	"github.com/dtan4/valec/msg"
-	"github.com/dtan4/valec/secret"
-	"github.com/dtan4/valec/util"
+	"github.com/dtan4/valec/plan"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
 // syncCmd represents the sync command
 var syncCmd = &cobra.Command{
-	Use:   "sync SECRETDIR [NAMESPACE]",
+	Use:   "sync SECRETDIR [NAMESPACE...]",
 	Short: "Synchronize secrets between local file and DynamoDB",
-	RunE:  doSync,
+	Long: `Synchronize secrets between local file and DynamoDB
+
+If --output json is given, result is printed as JSON report instead of messages.
+
+Exit status is 0 if there is no change left, 2 if some changes are planned
+but not applied (e.g. --dry-run), and 1 if synchronization failed.
+
+Deleted secrets and namespaces are kept in trash, and can be restored by
+valec trash restore.`,
+	RunE: doSync,
 }
 
+const (
+	defaultParallel = 4
+	outputJSON      = "json"
+	outputText      = "text"
+)
+
 var syncOpts = struct {
-	dryRun bool
+	allowUnbound bool
+	dryRun       bool
+	exclude      []string
+	force        bool
+	only         []string
+	output       string
+	parallel     int
+	protected    []string
+	prune        bool
+	yes          bool
 }{}
 
 func doSync(cmd *cobra.Command, args []string) error {
@@ -32,118 +55,94 @@ func doSync(cmd *cobra.Command, args []string) error {
 		msg.DisableColor()
 	}
 
-	files, err := util.ListYAMLFiles(dirname)
-	if err != nil {
-		return errors.Wrapf(err, "Failed to read directory. dirname=%s", dirname)
+	if syncOpts.output != outputText && syncOpts.output != outputJSON {
+		return errors.Errorf("Unknown output format. output=%s", syncOpts.output)
 	}
 
-	srcNamespaces, err := aws.DynamoDB.ListNamespaces(rootOpts.tableName)
-	if err != nil {
-		return errors.Wrap(err, "Failed to retrieve namespaces.")
-	}
+	jsonOutput := syncOpts.output == outputJSON
 
-	dstNamespaces := []string{}
+	// Messages are suppressed so that only JSON report is printed to stdout
+	if jsonOutput {
+		stdout = ioutil.Discard
+		msg.SetOutput(ioutil.Discard)
+	}
 
-	for _, file := range files {
-		namespace, err := util.NamespaceFromPath(file, dirname)
-		if err != nil {
-			return errors.Wrap(err, "Failed to get namespace.")
-		}
-		dstNamespaces = append(dstNamespaces, namespace)
+	report, err := syncSecrets(dirname, args[1:])
 
-		if err := syncFile(file, namespace); err != nil {
-			return errors.Wrapf(err, "Failed to synchronize file. filename=%s", file)
+	if jsonOutput {
+		if err := report.Write(os.Stdout); err != nil {
+			return errors.Wrap(err, "Failed to print report.")
 		}
 	}
 
-	_, deleted := util.CompareStrings(srcNamespaces, dstNamespaces)
-
-	for _, namespace := range deleted {
-		msg.RedBold.Printf("- %s\n", namespace)
+	if err != nil {
+		return err
 	}
 
-	if len(deleted) > 0 {
-		fmt.Printf("%d namespaces will be deleted.\n", len(deleted))
-
-		if !syncOpts.dryRun {
-			for _, namespace := range deleted {
-				if err := aws.DynamoDB.DeleteNamespace(rootOpts.tableName, namespace); err != nil {
-					return errors.Wrapf(err, "Failed to delete namespace. namespace=%s", namespace)
-				}
-			}
-
-			fmt.Printf("%d namespaces were successfully deleted.\n", len(deleted))
-		}
+	if report.Pending() {
+		exitCode = exitCodePending
 	}
 
 	return nil
 }
 
-func syncFile(filename, namespace string) error {
-	msg.Bold.Println(namespace)
-
-	_, srcSecrets, err := secret.LoadFromYAML(filename)
+// syncSecrets synchronizes secret files in the given directory, and returns report of the result
+// Report is returned even if an error occurred.
+func syncSecrets(dirname string, namespaces []string) (*plan.Report, error) {
+	p, failures, err := makePlan(dirname, planOptions{
+		scope: plan.Scope{
+			Namespaces: namespaces,
+			Only:       syncOpts.only,
+			Exclude:    syncOpts.exclude,
+		},
+		force:        syncOpts.force,
+		prune:        syncOpts.prune,
+		protected:    syncOpts.protected,
+		parallel:     syncOpts.parallel,
+		allowUnbound: syncOpts.allowUnbound,
+	})
 	if err != nil {
-		return errors.Wrapf(err, "Failed to load secrets. filename=%s", filename)
-	}
-
-	dstSecrets, err := aws.DynamoDB.ListSecrets(rootOpts.tableName, namespace)
-	if err != nil {
-		return errors.Wrapf(err, "Failed to retrieve secrets. namespace=%s", namespace)
-	}
+		err = errors.Wrap(err, "Failed to make plan.")
 
-	added, updated, deleted := srcSecrets.CompareList(dstSecrets)
+		report := plan.NewReport(&plan.Plan{Table: rootOpts.tableName}, syncOpts.dryRun)
+		report.Fail(err)
 
-	if len(deleted) > 0 {
-		fmt.Printf("%  d secrets will be deleted.\n", len(deleted))
-		for _, secret := range deleted {
-			msg.Red.Printf("    - %s\n", secret.Key)
-		}
-
-		if !syncOpts.dryRun {
-			if err := aws.DynamoDB.Delete(rootOpts.tableName, namespace, deleted); err != nil {
-				return errors.Wrapf(err, "Failed to delete secrets. namespace=%s", namespace)
-			}
-
-			fmt.Printf("  %d secrets were successfully deleted.\n", len(deleted))
-		}
+		return report, err
 	}
 
-	if len(updated) > 0 {
-		fmt.Printf("  %d secrets will be updated.\n", len(updated))
-		for _, secret := range updated {
-			msg.Yellow.Printf("    + %s\n", secret.Key)
-		}
-
-		if !syncOpts.dryRun {
-			if err := aws.DynamoDB.Insert(rootOpts.tableName, namespace, updated); err != nil {
-				return errors.Wrapf(err, "Failed to insert secrets. namespace=%s")
-			}
+	report := plan.NewReport(p, syncOpts.dryRun)
 
-			fmt.Printf("  %d secrets were successfully updated.\n", len(updated))
-		}
+	for _, f := range failures {
+		report.FailNamespace(f.namespace, f.err)
 	}
 
-	if len(added) > 0 {
-		fmt.Printf("  %d secrets will be added.\n", len(added))
-		for _, secret := range added {
-			msg.Green.Printf("    + %s\n", secret.Key)
-		}
-
-		if !syncOpts.dryRun {
-			if err := aws.DynamoDB.Insert(rootOpts.tableName, namespace, added); err != nil {
-				return errors.Wrapf(err, "Failed to insert secrets. namespace=%s")
-			}
-
-			fmt.Printf("  %d secrets were successfully added.\n", len(added))
-		}
+	if err := runPlan(p, report, runOptions{
+		dryRun:      syncOpts.dryRun,
+		yes:         syncOpts.yes,
+		interactive: syncOpts.output == outputText,
+		parallel:    syncOpts.parallel,
+		summary:     true,
+	}); err != nil {
+		err = errors.Wrap(err, "Failed to synchronize secrets.")
+		report.Fail(err)
+
+		return report, err
 	}
 
-	return nil
+	return report, nil
 }
 
 func init() {
 	RootCmd.AddCommand(syncCmd)
 
+	syncCmd.Flags().BoolVar(&syncOpts.allowUnbound, "allow-unbound", false, "Allow writing secrets which are not bound to namespace")
 	syncCmd.Flags().BoolVar(&syncOpts.dryRun, "dry-run", false, "Dry run")
+	syncCmd.Flags().StringSliceVar(&syncOpts.exclude, "exclude", []string{}, "Glob patterns of namespaces to exclude")
+	syncCmd.Flags().BoolVar(&syncOpts.force, "force", false, "Update secrets even if only their cipher texts were changed")
+	syncCmd.Flags().StringSliceVar(&syncOpts.only, "only", []string{}, "Glob patterns of namespaces to include")
+	syncCmd.Flags().StringVar(&syncOpts.output, "output", outputText, "Output format (text, json)")
+	syncCmd.Flags().IntVar(&syncOpts.parallel, "parallel", defaultParallel, "Number of namespaces synchronized concurrently")
+	syncCmd.Flags().StringSliceVar(&syncOpts.protected, "protect", defaultProtectedNamespaces(), "Glob patterns of namespaces never to delete")
+	syncCmd.Flags().BoolVar(&syncOpts.prune, "prune", false, "Delete namespaces which do not have secret files")
+	syncCmd.Flags().BoolVarP(&syncOpts.yes, "yes", "y", false, "Delete namespaces without confirmation")
 }
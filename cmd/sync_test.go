@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"os"
+	"testing"
+
+	"github.com/dtan4/valec/aws"
+	"github.com/dtan4/valec/secret"
+)
+
+// keysOf returns keys of the given secrets
+func keysOf(secrets secret.Secrets) []string {
+	keys := []string{}
+
+	for _, s := range secrets {
+		keys = append(keys, s.Key)
+	}
+
+	return keys
+}
+
+func TestSyncSecrets_reencrypted(t *testing.T) {
+	dir, _ := setupTest(t)
+	defer os.RemoveAll(dir)
+
+	writeSecretFile(t, dir, "hoge", "hoge", "valec", map[string]string{"FOO": "bar"})
+
+	if _, err := syncSecrets(dir, []string{}); err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
+	}
+
+	// Cipher text differs, but value, key and encryption context are the same
+	writeSecretFile(t, dir, "hoge", "hoge", "valec", map[string]string{"FOO": "bar"})
+
+	p, _, err := makePlan(dir, planOptions{parallel: 1})
+	if err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
+	}
+
+	n := p.Namespaces[0]
+
+	if len(n.Updated) != 0 {
+		t.Errorf("No secret should be updated. actual: %q", keysOf(n.Updated))
+	}
+
+	if len(n.Reencrypted) != 1 || n.Reencrypted[0].Key != "FOO" {
+		t.Errorf("FOO should be only re-encrypted. actual: %q", keysOf(n.Reencrypted))
+	}
+}
+
+func TestSyncSecrets_rotate(t *testing.T) {
+	dir, s := setupTest(t)
+	defer os.RemoveAll(dir)
+
+	filename := writeSecretFile(t, dir, "hoge", "hoge", "valec", map[string]string{"FOO": "bar"})
+
+	if _, err := syncSecrets(dir, []string{}); err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
+	}
+
+	if err := rotateFile(filename, "hoge", "valec-new"); err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
+	}
+
+	p, _, err := makePlan(dir, planOptions{parallel: 1})
+	if err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
+	}
+
+	n := p.Namespaces[0]
+
+	if len(n.Updated) != 1 || n.Updated[0].Key != "FOO" {
+		t.Errorf("FOO should be updated. actual: %q", keysOf(n.Updated))
+	}
+
+	if len(n.Reencrypted) != 0 {
+		t.Errorf("No secret should be skipped. actual: %q", keysOf(n.Reencrypted))
+	}
+
+	if _, err := syncSecrets(dir, []string{}); err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
+	}
+
+	expected := "arn:aws:kms:ap-northeast-1:123456789012:key/2"
+
+	actual, err := aws.KMS.KeyARNOfCipherText("hoge", "FOO", s.namespaces["hoge"]["FOO"].Value)
+	if err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
+	}
+
+	if actual != expected {
+		t.Errorf("Stored secret should be encrypted with the new key. expected: %q, actual: %q", expected, actual)
+	}
+
+	if actual := s.namespaces["hoge"]["FOO"].KeyARN; actual != expected {
+		t.Errorf("New key should be recorded. expected: %q, actual: %q", expected, actual)
+	}
+}
+
+func TestSyncSecrets_migrateContext(t *testing.T) {
+	dir, s := setupTest(t)
+	defer os.RemoveAll(dir)
+
+	// Secrets encrypted by older valec are not bound to namespace
+	filename := writeSecretFile(t, dir, "hoge", "", "valec", map[string]string{"FOO": "bar"})
+
+	y, err := secret.LoadYAML(filename)
+	if err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
+	}
+
+	s.Apply(testTable, "hoge", y.Secrets, []*secret.Secret{}, []*secret.Secret{})
+
+	if err := migrateContextFile(filename, "hoge"); err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
+	}
+
+	p, _, err := makePlan(dir, planOptions{parallel: 1})
+	if err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
+	}
+
+	n := p.Namespaces[0]
+
+	if len(n.Updated) != 1 || n.Updated[0].Key != "FOO" {
+		t.Errorf("FOO should be updated. actual: %q", keysOf(n.Updated))
+	}
+
+	if len(n.Reencrypted) != 0 {
+		t.Errorf("No secret should be skipped. actual: %q", keysOf(n.Reencrypted))
+	}
+
+	if _, err := syncSecrets(dir, []string{}); err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
+	}
+
+	actual, err := decryptSecretInNamespace("hoge", s.namespaces["hoge"]["FOO"])
+	if err != nil {
+		t.Fatalf("Stored secret should be bound to namespace. error: %s", err)
+	}
+
+	if actual != "bar" {
+		t.Errorf("Value does not match. expected: %q, actual: %q", "bar", actual)
+	}
+}
+
+func TestSyncSecrets_unbound(t *testing.T) {
+	dir, s := setupTest(t)
+	defer os.RemoveAll(dir)
+
+	writeSecretFile(t, dir, "hoge", "", "valec", map[string]string{"FOO": "bar"})
+
+	if _, err := syncSecrets(dir, []string{}); err == nil {
+		t.Fatalf("Error should be raised.")
+	}
+
+	if len(s.namespaces["hoge"]) != 0 {
+		t.Errorf("Unbound secrets should not be written.")
+	}
+
+	syncOpts.allowUnbound = true
+
+	if _, err := syncSecrets(dir, []string{}); err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
+	}
+
+	if len(s.namespaces["hoge"]) != 1 {
+		t.Errorf("Unbound secrets should be written with --allow-unbound.")
+	}
+}
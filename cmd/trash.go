@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/dtan4/valec/aws"
+	"github.com/dtan4/valec/msg"
+	"github.com/dtan4/valec/secret"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// trashCmd represents the trash command
+var trashCmd = &cobra.Command{
+	Use:   "trash",
+	Short: "Inspect and restore deleted secrets",
+	Long: `Inspect and restore deleted secrets
+
+Secrets deleted by valec sync or valec apply are moved to trash, and kept
+until they expire. Deleted namespaces are also moved to trash. Expired
+secrets are removed only if DynamoDB TTL is enabled on expires_at attribute,
+and their cipher texts are still kept in history.
+
+To list deleted secrets in all namespaces:
+  $ valec trash list
+To list deleted secrets in namespace:
+  $ valec trash list NAMESPACE
+To restore all deleted secrets in namespace:
+  $ valec trash restore NAMESPACE
+To restore some of deleted secrets in namespace:
+  $ valec trash restore NAMESPACE KEY1 KEY2`,
+}
+
+// trashListCmd represents the trash list command
+var trashListCmd = &cobra.Command{
+	Use:   "list [NAMESPACE]",
+	Short: "List deleted secrets",
+	RunE:  doTrashList,
+}
+
+// trashRestoreCmd represents the trash restore command
+var trashRestoreCmd = &cobra.Command{
+	Use:   "restore NAMESPACE [KEY ...]",
+	Short: "Restore deleted secrets",
+	Long: `Restore deleted secrets
+
+The latest deleted secret of each key is restored. Secrets which exist already
+are not overwritten.
+
+Local secret file does not hold restored secrets. Please update it by valec pull,
+otherwise the next valec sync deletes them again.`,
+	RunE: doTrashRestore,
+}
+
+func doTrashList(cmd *cobra.Command, args []string) error {
+	var namespace string
+
+	if len(args) > 1 {
+		return errors.New("Please specify at most one namespace.")
+	}
+
+	if len(args) == 1 {
+		namespace = args[0]
+	}
+
+	trashed, err := secretStore.ListTrash(rootOpts.tableName, namespace)
+	if err != nil {
+		return errors.Wrap(err, "Failed to retrieve trash.")
+	}
+
+	if len(trashed) == 0 {
+		fmt.Println("Trash is empty.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tKEY\tVERSION\tDELETED AT\tEXPIRES AT")
+
+	for _, t := range trashed {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", t.Namespace, t.Secret.Key, strconv.FormatInt(t.Secret.Version, 10), formatTime(t.DeletedAt), formatTime(t.ExpiresAt))
+	}
+
+	w.Flush()
+
+	return nil
+}
+
+func doTrashRestore(cmd *cobra.Command, args []string) error {
+	if len(args) < 1 {
+		return errors.New("Please specify namespace.")
+	}
+	namespace, keys := args[0], args[1:]
+
+	trashed, err := secretStore.ListTrash(rootOpts.tableName, namespace)
+	if err != nil {
+		return errors.Wrap(err, "Failed to retrieve trash.")
+	}
+
+	targets, err := latestTrashed(trashed, keys)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to find deleted secrets. namespace=%s", namespace)
+	}
+
+	if len(targets) == 0 {
+		return errors.Errorf("No deleted secret in trash. namespace=%s", namespace)
+	}
+
+	identity, err := aws.STS.CallerIdentity()
+	if err != nil {
+		return errors.Wrap(err, "Failed to retrieve caller identity.")
+	}
+
+	for _, t := range targets {
+		t.Secret.UpdatedBy = identity
+		// Restored secrets are not written from secret files
+		t.Secret.Commit = ""
+
+		recordAudit(namespace, t.Secret.Key)
+	}
+
+	err = secretStore.Restore(rootOpts.tableName, namespace, targets)
+
+	conflicted := map[string]bool{}
+
+	if conflict, ok := errors.Cause(err).(*secret.ConflictError); ok {
+		for _, key := range conflict.Keys {
+			conflicted[key] = true
+			msg.Red.Printf("  ! %s exists already.\n", key)
+		}
+	} else if err != nil {
+		return errors.Wrap(err, "Failed to restore secrets.")
+	}
+
+	restored := 0
+
+	for _, t := range targets {
+		if !conflicted[t.Secret.Key] {
+			msg.Green.Printf("  + %s\n", t.Secret.Key)
+			restored++
+		}
+	}
+
+	fmt.Printf("%d secrets were successfully restored.\n", restored)
+
+	if err != nil {
+		return errors.Wrap(err, "Failed to restore secrets.")
+	}
+
+	return nil
+}
+
+// latestTrashed returns the latest deleted secret of each key, or of the given keys only
+// Deleted secrets are ordered by key and deleted time.
+func latestTrashed(trashed []*secret.Trashed, keys []string) ([]*secret.Trashed, error) {
+	latest := map[string]*secret.Trashed{}
+	order := []string{}
+
+	for _, t := range trashed {
+		if _, ok := latest[t.Secret.Key]; !ok {
+			order = append(order, t.Secret.Key)
+		}
+
+		latest[t.Secret.Key] = t
+	}
+
+	if len(keys) == 0 {
+		keys = order
+	}
+
+	targets := []*secret.Trashed{}
+
+	for _, key := range keys {
+		t, ok := latest[key]
+		if !ok {
+			return []*secret.Trashed{}, errors.Errorf("Secret is not found in trash. key=%s", key)
+		}
+
+		targets = append(targets, t)
+	}
+
+	return targets, nil
+}
+
+func init() {
+	RootCmd.AddCommand(trashCmd)
+
+	trashCmd.AddCommand(trashListCmd)
+	trashCmd.AddCommand(trashRestoreCmd)
+}
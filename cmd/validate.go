@@ -3,7 +3,7 @@ package cmd
 import (
 	"fmt"
 
-	"github.com/dtan4/valec/aws"
+	"github.com/dtan4/valec/provider"
 	"github.com/dtan4/valec/secret"
 	"github.com/dtan4/valec/util"
 	"github.com/fatih/color"
@@ -30,7 +30,12 @@ func doValidate(cmd *cobra.Command, args []string) error {
 	}
 
 	for _, file := range files {
-		if err := validateFile(file); err != nil {
+		namespace, err := util.NamespaceFromPath(file, dirname)
+		if err != nil {
+			return errors.Wrap(err, "Failed to get namespace.")
+		}
+
+		if err := validateFile(file, namespace); err != nil {
 			return errors.Wrapf(err, "Failed to validate file. filename=%s", file)
 		}
 	}
@@ -38,21 +43,70 @@ func doValidate(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func validateFile(filename string) error {
+func validateFile(filename, namespace string) error {
 	fmt.Println(filename)
 
-	_, secrets, err := secret.LoadFromYAML(filename)
+	y, err := secret.LoadYAML(filename)
 	if err != nil {
 		return errors.Wrapf(err, "Failed to load secrets. filename=%s", filename)
 	}
 
+	providerName := provider.NameOrDefault(y.Provider)
+
 	hasError := false
 	green := color.New(color.FgGreen)
 	red := color.New(color.FgRed)
+	yellow := color.New(color.FgYellow)
+	unbound := 0
+
+	for _, secret := range y.Secrets {
+		if len(secret.Replicas) != len(y.Keys())-1 {
+			red.Printf("  Secret value is not encrypted with all KMS keys. Please try `valec encrypt`. key=%s\n", secret.Key)
+			hasError = true
+			continue
+		}
+
+		bound := true
+
+		for i, cipherText := range cipherTextsOf(secret) {
+			label := secret.Key
+			if i > 0 {
+				label = fmt.Sprintf("%s, replica=%d, region=%s", secret.Key, i, cipherText.Region)
+			}
+
+			if name, _ := provider.Parse(cipherText.Value); name != providerName {
+				red.Printf("  Secret value is not encrypted by %s provider. Please try `valec encrypt`. key=%s, provider=%s\n", providerName, label, name)
+				hasError = true
+				continue
+			}
+
+			if _, err := decryptCipherText(namespace, secret, cipherText); err == nil {
+				continue
+			}
+
+			if _, err := decryptCipherText("", secret, cipherText); err == nil {
+				bound = false
+				continue
+			}
 
-	for _, secret := range secrets {
-		if _, err := aws.KMS.DecryptBase64(secret.Key, secret.Value); err != nil {
-			red.Printf("  Secret value is invalid. Please try `valec encrypt`. key=%s\n", secret.Key)
+			red.Printf("  Secret value is invalid. Please try `valec encrypt`. key=%s\n", label)
+			hasError = true
+		}
+
+		if !bound {
+			yellow.Printf("  Secret value is not bound to namespace %s. key=%s\n", namespace, secret.Key)
+			unbound++
+		}
+	}
+
+	if !hasError {
+		mismatched, err := verifyKMSKey(namespace, y)
+		if err != nil {
+			return errors.Wrap(err, "Failed to verify KMS key.")
+		}
+
+		for _, key := range mismatched {
+			red.Printf("  Secret value is not encrypted with key %s. Please try `valec encrypt`. key=%s\n", kmsKeyAliases(y), key)
 			hasError = true
 		}
 	}
@@ -61,6 +115,11 @@ func validateFile(filename string) error {
 		return errors.New("Some secrets are invalid.")
 	}
 
+	if unbound > 0 {
+		yellow.Printf("  %d secrets are not bound to namespace. Please try `valec migrate-context`.\n", unbound)
+		return nil
+	}
+
 	green.Println("  All secrets are valid.")
 
 	return nil
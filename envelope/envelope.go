@@ -0,0 +1,114 @@
+package envelope
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	dataKeySize = 32
+)
+
+// KeyManager represents the interface of key management service which wraps data keys
+type KeyManager interface {
+	DecryptDataKey(dataKey string) ([]byte, error)
+}
+
+// Client represents the encryption provider sealing values locally with data key
+type Client struct {
+	keyManager KeyManager
+	dataKey    string
+
+	once      sync.Once
+	plainKey  []byte
+	unwrapErr error
+}
+
+// NewClient creates new Client object
+// dataKey is the base64-encoded data key wrapped by keyManager.
+func NewClient(keyManager KeyManager, dataKey string) *Client {
+	return &Client{
+		keyManager: keyManager,
+		dataKey:    dataKey,
+	}
+}
+
+// DecryptBase64 decrypts the given base64-encoded cipher text
+func (c *Client) DecryptBase64(namespace, key, cipherText string) (string, error) {
+	aead, err := c.aead()
+	if err != nil {
+		return "", err
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(cipherText)
+	if err != nil {
+		return "", errors.Wrapf(err, "Failed to decode as base64 string. text=%q", cipherText)
+	}
+
+	if len(decoded) < aead.NonceSize() {
+		return "", errors.Errorf("Cipher text is too short. key=%s", key)
+	}
+
+	plaintext, err := aead.Open(nil, decoded[:aead.NonceSize()], decoded[aead.NonceSize():], encryptionContext(namespace, key))
+	if err != nil {
+		return "", errors.Wrapf(err, "Failed to decrypt the given cipherText. namespace=%s, key=%s, cipherText=%q", namespace, key, cipherText)
+	}
+
+	return string(plaintext), nil
+}
+
+// EncryptBase64 encrypts the given text and returns as base64-encoded cipher text
+// keyAlias is ignored because values are encrypted with the data key given to NewClient.
+func (c *Client) EncryptBase64(keyAlias, namespace, key, text string) (string, error) {
+	aead, err := c.aead()
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", errors.Wrap(err, "Failed to generate nonce.")
+	}
+
+	return base64.StdEncoding.EncodeToString(aead.Seal(nonce, nonce, []byte(text), encryptionContext(namespace, key))), nil
+}
+
+func (c *Client) aead() (cipher.AEAD, error) {
+	if c.dataKey == "" {
+		return nil, errors.New("Data key is not given.")
+	}
+
+	// Data key is decrypted only once, so that one secret file / namespace needs only one API call
+	c.once.Do(func() {
+		c.plainKey, c.unwrapErr = c.keyManager.DecryptDataKey(c.dataKey)
+	})
+	if c.unwrapErr != nil {
+		return nil, errors.Wrap(c.unwrapErr, "Failed to decrypt data key.")
+	}
+
+	if len(c.plainKey) != dataKeySize {
+		return nil, errors.Errorf("Invalid data key length. length=%d", len(c.plainKey))
+	}
+
+	block, err := aes.NewCipher(c.plainKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to initialize AES cipher.")
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// encryptionContext returns additional authenticated data equivalent to KMS encryption context
+func encryptionContext(namespace, key string) []byte {
+	if namespace == "" {
+		return []byte("key=" + key)
+	}
+
+	return []byte("key=" + key + "\nnamespace=" + namespace)
+}
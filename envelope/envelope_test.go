@@ -0,0 +1,73 @@
+package envelope
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+type fakeKeyManager struct {
+	calls int
+}
+
+func (m *fakeKeyManager) DecryptDataKey(dataKey string) ([]byte, error) {
+	m.calls++
+
+	if dataKey != "wrapped" {
+		return []byte{}, errors.New("Unknown data key.")
+	}
+
+	return bytes.Repeat([]byte{0x42}, dataKeySize), nil
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	keyManager := &fakeKeyManager{}
+	client := NewClient(keyManager, "wrapped")
+
+	cipherText, err := client.EncryptBase64("valec", "production", "FOO", "bar")
+	if err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
+	}
+
+	actual, err := client.DecryptBase64("production", "FOO", cipherText)
+	if err != nil {
+		t.Errorf("Error should not be raised. error: %s", err)
+	}
+
+	if actual != "bar" {
+		t.Errorf("Plain text does not match. expected: %q, actual: %q", "bar", actual)
+	}
+
+	if _, err := client.DecryptBase64("production", "BAZ", cipherText); err == nil {
+		t.Errorf("Error should be raised with another key.")
+	}
+
+	if _, err := client.DecryptBase64("staging", "FOO", cipherText); err == nil {
+		t.Errorf("Error should be raised with another namespace.")
+	}
+
+	if _, err := client.DecryptBase64("", "FOO", cipherText); err == nil {
+		t.Errorf("Error should be raised without namespace.")
+	}
+
+	if keyManager.calls != 1 {
+		t.Errorf("Data key should be decrypted only once. calls: %d", keyManager.calls)
+	}
+}
+
+func TestDecryptBase64_invalidDataKey(t *testing.T) {
+	client := NewClient(&fakeKeyManager{}, "foobar")
+
+	if _, err := client.DecryptBase64("production", "FOO", "AAAA"); err == nil {
+		t.Errorf("Error should be raised.")
+	}
+}
+
+func TestEncryptBase64_noDataKey(t *testing.T) {
+	client := NewClient(&fakeKeyManager{}, "")
+
+	if _, err := client.EncryptBase64("valec", "production", "FOO", "bar"); err == nil {
+		t.Errorf("Error should be raised.")
+	}
+}
@@ -1,6 +1,9 @@
 package msg
 
 import (
+	"fmt"
+	"io"
+
 	"github.com/fatih/color"
 )
 
@@ -23,3 +26,14 @@ var (
 func DisableColor() {
 	color.NoColor = true
 }
+
+// SetOutput changes where colorized messages are written
+func SetOutput(w io.Writer) {
+	color.Output = w
+}
+
+// Fprintf writes message colorized with the given color to w
+// Messages of concurrent tasks are written to their own buffers with this.
+func Fprintf(w io.Writer, c *color.Color, format string, a ...interface{}) {
+	fmt.Fprint(w, c.SprintfFunc()(format, a...))
+}
@@ -1,6 +1,7 @@
 package msg
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/fatih/color"
@@ -15,3 +16,46 @@ func TestDisableColor(t *testing.T) {
 		t.Error("color.Nocolor should be true.")
 	}
 }
+
+func TestSetOutput(t *testing.T) {
+	output := color.Output
+	defer func() { color.Output = output }()
+
+	color.NoColor = true
+
+	var buf bytes.Buffer
+	SetOutput(&buf)
+
+	Red.Printf("hoge %s", "fuga")
+
+	if buf.String() != "hoge fuga" {
+		t.Errorf("output does not match. expected: %q, actual: %q", "hoge fuga", buf.String())
+	}
+}
+
+func TestFprintf(t *testing.T) {
+	testcases := []struct {
+		noColor  bool
+		expected string
+	}{
+		{
+			noColor:  true,
+			expected: "hoge fuga\n",
+		},
+		{
+			noColor:  false,
+			expected: "\x1b[31mhoge fuga\n\x1b[0m",
+		},
+	}
+
+	for _, tc := range testcases {
+		color.NoColor = tc.noColor
+
+		var buf bytes.Buffer
+		Fprintf(&buf, Red, "hoge %s\n", "fuga")
+
+		if buf.String() != tc.expected {
+			t.Errorf("output does not match. expected: %q, actual: %q", tc.expected, buf.String())
+		}
+	}
+}
@@ -0,0 +1,260 @@
+package plan
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"io/ioutil"
+	"path"
+	"sort"
+
+	"github.com/dtan4/valec/secret"
+	"github.com/pkg/errors"
+)
+
+const (
+	// Version represents the version of plan file format
+	Version = 1
+)
+
+// Plan represents changes required to synchronize local secret files to secret storage
+type Plan struct {
+	Version int
+	Table   string
+	Scope   Scope
+
+	// Commit is the git commit of secret files which the plan was made from
+	Commit string
+
+	// NamespacesDigest is the digest of namespaces in scope which existed when the plan was made
+	NamespacesDigest string
+
+	Namespaces        []*Namespace
+	DeletedNamespaces []*Namespace
+
+	// UnprunedNamespaces exist only in secret storage, but are not deleted without prune
+	UnprunedNamespaces []string
+	// ProtectedNamespaces exist only in secret storage, but are never deleted
+	ProtectedNamespaces []string
+}
+
+// Namespace represents changes of secrets in one namespace
+type Namespace struct {
+	Name string
+
+	// RemoteDigest is the digest of secrets which were stored when the plan was made
+	RemoteDigest string
+
+	Added   secret.Secrets
+	Updated secret.Secrets
+	Deleted secret.Secrets
+
+	// Reencrypted are secrets whose cipher texts differ but plain values are the same
+	// They are not written unless they are moved to Updated.
+	Reencrypted secret.Secrets
+
+	// Fingerprints are fingerprints of old and new plain values of updated secrets
+	Fingerprints map[string]string
+
+	// Originals are stored secrets which Updated overwrite, used to revert changes on failure
+	Originals secret.Secrets
+}
+
+// Scope represents namespaces to synchronize
+// Empty scope includes all namespaces.
+type Scope struct {
+	// Namespaces are namespaces given explicitly
+	Namespaces []string
+	// Only are glob patterns of namespaces to include
+	Only []string
+	// Exclude are glob patterns of namespaces to exclude
+	Exclude []string
+}
+
+// New creates new empty Plan
+// Namespaces out of the given scope are ignored.
+func New(table string, scope Scope, namespaces []string) *Plan {
+	return &Plan{
+		Version:          Version,
+		Table:            table,
+		Scope:            scope,
+		NamespacesDigest: DigestStrings(scope.Filter(namespaces)),
+	}
+}
+
+// Protect removes namespaces which match the given glob patterns from namespaces to delete
+func (p *Plan) Protect(patterns []string) {
+	deleted := []*Namespace{}
+
+	for _, n := range p.DeletedNamespaces {
+		if matchAny(patterns, n.Name) {
+			p.ProtectedNamespaces = append(p.ProtectedNamespaces, n.Name)
+		} else {
+			deleted = append(deleted, n)
+		}
+	}
+
+	p.DeletedNamespaces = deleted
+}
+
+// Validate checks that all patterns in scope are valid
+func (s Scope) Validate() error {
+	return ValidatePatterns(append(append([]string{}, s.Only...), s.Exclude...))
+}
+
+// ValidatePatterns checks that all the given namespace glob patterns are valid
+func ValidatePatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return errors.Wrapf(err, "Invalid namespace pattern. pattern=%s", pattern)
+		}
+	}
+
+	return nil
+}
+
+// Includes returns whether the given namespace is in scope
+func (s Scope) Includes(namespace string) bool {
+	if len(s.Namespaces) > 0 && !contains(s.Namespaces, namespace) {
+		return false
+	}
+
+	if len(s.Only) > 0 && !matchAny(s.Only, namespace) {
+		return false
+	}
+
+	return !matchAny(s.Exclude, namespace)
+}
+
+// Filter returns namespaces in scope
+func (s Scope) Filter(namespaces []string) []string {
+	filtered := []string{}
+
+	for _, namespace := range namespaces {
+		if s.Includes(namespace) {
+			filtered = append(filtered, namespace)
+		}
+	}
+
+	return filtered
+}
+
+func contains(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchAny(patterns []string, namespace string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, namespace); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasChanges returns whether the plan has any change to apply
+func (p *Plan) HasChanges() bool {
+	if len(p.DeletedNamespaces) > 0 {
+		return true
+	}
+
+	for _, n := range p.Namespaces {
+		if n.HasChanges() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasChanges returns whether the namespace has any change to apply
+func (n *Namespace) HasChanges() bool {
+	return len(n.Added) > 0 || len(n.Updated) > 0 || len(n.Deleted) > 0
+}
+
+// Save saves the plan to the given file
+// Plan files contain only cipher texts, never plain values.
+func (p *Plan) Save(filename string) error {
+	var buf bytes.Buffer
+
+	if err := gob.NewEncoder(&buf).Encode(p); err != nil {
+		return errors.Wrap(err, "Failed to encode plan.")
+	}
+
+	if err := ioutil.WriteFile(filename, buf.Bytes(), 0600); err != nil {
+		return errors.Wrapf(err, "Failed to save file. filename=%s", filename)
+	}
+
+	return nil
+}
+
+// Load loads plan from the given file
+func Load(filename string) (*Plan, error) {
+	body, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to read plan file. filename=%s", filename)
+	}
+
+	var p Plan
+
+	if err := gob.NewDecoder(bytes.NewReader(body)).Decode(&p); err != nil {
+		return nil, errors.Wrapf(err, "Failed to parse plan file. filename=%s", filename)
+	}
+
+	if p.Version != Version {
+		return nil, errors.Errorf("Unsupported plan file version. version=%d", p.Version)
+	}
+
+	return &p, nil
+}
+
+// Digest returns the digest of the given secrets
+// The order of secrets does not matter, but every cipher text, data key and region does.
+func Digest(secrets secret.Secrets) string {
+	sorted := make(secret.Secrets, len(secrets))
+	copy(sorted, secrets)
+	sort.Sort(sorted)
+
+	h := sha256.New()
+
+	for _, s := range sorted {
+		fields := []string{s.Key, s.Value, s.DataKey, s.Region}
+		for _, r := range s.Replicas {
+			fields = append(fields, r.Region, r.Value)
+		}
+
+		for _, field := range fields {
+			h.Write([]byte(field))
+			h.Write([]byte{0})
+		}
+
+		h.Write([]byte{'\n'})
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// DigestStrings returns the digest of the given strings
+// The order of strings does not matter.
+func DigestStrings(ss []string) string {
+	sorted := make([]string, len(ss))
+	copy(sorted, ss)
+	sort.Strings(sorted)
+
+	h := sha256.New()
+
+	for _, s := range sorted {
+		h.Write([]byte(s))
+		h.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
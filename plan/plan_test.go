@@ -0,0 +1,282 @@
+package plan
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/dtan4/valec/secret"
+)
+
+func TestHasChanges(t *testing.T) {
+	testcases := []struct {
+		plan     *Plan
+		expected bool
+	}{
+		{
+			plan: &Plan{
+				Namespaces: []*Namespace{
+					&Namespace{
+						Name: "hoge",
+						Reencrypted: secret.Secrets{
+							&secret.Secret{Key: "FOO", Value: "bar"},
+						},
+					},
+				},
+			},
+			expected: false,
+		},
+		{
+			plan: &Plan{
+				Namespaces: []*Namespace{
+					&Namespace{
+						Name: "hoge",
+					},
+					&Namespace{
+						Name: "fuga",
+						Deleted: secret.Secrets{
+							&secret.Secret{Key: "FOO", Value: "bar"},
+						},
+					},
+				},
+			},
+			expected: true,
+		},
+		{
+			plan: &Plan{
+				DeletedNamespaces: []*Namespace{
+					&Namespace{
+						Name: "hoge",
+					},
+				},
+			},
+			expected: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		if actual := tc.plan.HasChanges(); actual != tc.expected {
+			t.Errorf("Result does not match. expected: %t, actual: %t", tc.expected, actual)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	p := New("valec", Scope{Exclude: []string{"fuga"}}, []string{"hoge", "fuga"})
+
+	if p.NamespacesDigest != DigestStrings([]string{"hoge"}) {
+		t.Errorf("Namespaces out of scope should be ignored.")
+	}
+}
+
+func TestScopeIncludes(t *testing.T) {
+	testcases := []struct {
+		scope     Scope
+		namespace string
+		expected  bool
+	}{
+		{
+			scope:     Scope{},
+			namespace: "prod/app",
+			expected:  true,
+		},
+		{
+			scope:     Scope{Namespaces: []string{"hoge", "prod/app"}},
+			namespace: "prod/app",
+			expected:  true,
+		},
+		{
+			scope:     Scope{Namespaces: []string{"hoge"}},
+			namespace: "prod/app",
+			expected:  false,
+		},
+		{
+			scope:     Scope{Only: []string{"prod/*"}},
+			namespace: "prod/app",
+			expected:  true,
+		},
+		{
+			scope:     Scope{Only: []string{"prod/*"}},
+			namespace: "staging/app",
+			expected:  false,
+		},
+		{
+			scope:     Scope{Only: []string{"prod/*"}, Exclude: []string{"*/app"}},
+			namespace: "prod/app",
+			expected:  false,
+		},
+		{
+			scope:     Scope{Namespaces: []string{"prod/app"}, Only: []string{"staging/*"}},
+			namespace: "prod/app",
+			expected:  false,
+		},
+	}
+
+	for _, tc := range testcases {
+		if actual := tc.scope.Includes(tc.namespace); actual != tc.expected {
+			t.Errorf("Result does not match. scope: %#v, namespace: %s, expected: %t", tc.scope, tc.namespace, tc.expected)
+		}
+	}
+}
+
+func TestScopeFilter(t *testing.T) {
+	scope := Scope{Exclude: []string{"prod/*"}}
+	expected := []string{"hoge", "staging/app"}
+
+	if actual := scope.Filter([]string{"hoge", "prod/app", "staging/app"}); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Namespaces does not match. expected: %q, actual: %q", expected, actual)
+	}
+}
+
+func TestScopeValidate(t *testing.T) {
+	if err := (Scope{Only: []string{"prod/*"}, Exclude: []string{"prod/[ab]*"}}).Validate(); err != nil {
+		t.Errorf("Error should not be raised. error: %s", err)
+	}
+
+	if err := (Scope{Exclude: []string{"prod/[*"}}).Validate(); err == nil {
+		t.Errorf("Error should be raised.")
+	}
+}
+
+func TestProtect(t *testing.T) {
+	p := &Plan{
+		DeletedNamespaces: []*Namespace{
+			&Namespace{Name: "hoge"},
+			&Namespace{Name: "prod/app"},
+			&Namespace{Name: "staging/app"},
+		},
+	}
+
+	p.Protect([]string{"prod/*", "fuga"})
+
+	deleted := []string{}
+	for _, n := range p.DeletedNamespaces {
+		deleted = append(deleted, n.Name)
+	}
+
+	if expected := []string{"hoge", "staging/app"}; !reflect.DeepEqual(deleted, expected) {
+		t.Errorf("Namespaces to delete do not match. expected: %q, actual: %q", expected, deleted)
+	}
+
+	if expected := []string{"prod/app"}; !reflect.DeepEqual(p.ProtectedNamespaces, expected) {
+		t.Errorf("Protected namespaces do not match. expected: %q, actual: %q", expected, p.ProtectedNamespaces)
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "valec-plan")
+	if err != nil {
+		t.Fatalf("Failed to create temporary directory. error: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "plan.bin")
+
+	p := New("valec", Scope{Only: []string{"hoge"}}, []string{"hoge", "fuga"})
+	p.Namespaces = []*Namespace{
+		&Namespace{
+			Name:         "hoge",
+			RemoteDigest: "abcd",
+			Updated: secret.Secrets{
+				&secret.Secret{
+					Key:     "FOO",
+					Value:   "bar",
+					DataKey: "datakey",
+					Region:  "ap-northeast-1",
+					Replicas: []*secret.Replica{
+						&secret.Replica{
+							Region: "us-east-1",
+							Value:  "baz",
+						},
+					},
+				},
+			},
+			Fingerprints: map[string]string{
+				"FOO": "hmac:00000000 -> hmac:11111111",
+			},
+		},
+	}
+
+	if err := p.Save(filename); err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
+	}
+
+	actual, err := Load(filename)
+	if err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
+	}
+
+	if !reflect.DeepEqual(actual, p) {
+		t.Errorf("Plan does not match. expected: %#v, actual: %#v", p, actual)
+	}
+}
+
+func TestLoad_version(t *testing.T) {
+	dir, err := ioutil.TempDir("", "valec-plan")
+	if err != nil {
+		t.Fatalf("Failed to create temporary directory. error: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "plan.bin")
+
+	p := New("valec", Scope{}, []string{})
+	p.Version = Version + 1
+
+	if err := p.Save(filename); err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
+	}
+
+	if _, err := Load(filename); err == nil {
+		t.Errorf("Error should be raised.")
+	}
+}
+
+func TestDigest(t *testing.T) {
+	secrets := secret.Secrets{
+		&secret.Secret{Key: "FOO", Value: "bar"},
+		&secret.Secret{Key: "BAZ", Value: "1"},
+	}
+	reordered := secret.Secrets{
+		&secret.Secret{Key: "BAZ", Value: "1"},
+		&secret.Secret{Key: "FOO", Value: "bar"},
+	}
+	replicated := secret.Secrets{
+		&secret.Secret{Key: "BAZ", Value: "1"},
+		&secret.Secret{
+			Key:   "FOO",
+			Value: "bar",
+			Replicas: []*secret.Replica{
+				&secret.Replica{Region: "us-east-1", Value: "baz"},
+			},
+		},
+	}
+
+	if Digest(secrets) != Digest(reordered) {
+		t.Errorf("Digest should not depend on the order of secrets.")
+	}
+
+	if secrets[0].Key != "FOO" {
+		t.Errorf("Given secrets should not be sorted.")
+	}
+
+	if Digest(secrets) == Digest(replicated) {
+		t.Errorf("Digest should depend on replicas.")
+	}
+
+	if Digest(secrets) == Digest(secret.Secrets{}) {
+		t.Errorf("Digest should depend on secrets.")
+	}
+}
+
+func TestDigestStrings(t *testing.T) {
+	if DigestStrings([]string{"hoge", "fuga"}) != DigestStrings([]string{"fuga", "hoge"}) {
+		t.Errorf("Digest should not depend on the order of strings.")
+	}
+
+	if DigestStrings([]string{"hoge", "fuga"}) == DigestStrings([]string{"hogefuga"}) {
+		t.Errorf("Digest should distinguish strings.")
+	}
+}
@@ -0,0 +1,205 @@
+package plan
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/dtan4/valec/secret"
+	"github.com/pkg/errors"
+)
+
+const (
+	// StatusUnchanged represents that namespace has nothing to change
+	StatusUnchanged = "unchanged"
+	// StatusPlanned represents that changes of namespace are planned but not applied yet
+	StatusPlanned = "planned"
+	// StatusApplied represents that changes of namespace were applied
+	StatusApplied = "applied"
+	// StatusFailed represents that changes of namespace failed to be applied
+	StatusFailed = "failed"
+)
+
+const (
+	// ResultSucceeded represents that namespace is synchronized
+	ResultSucceeded = "succeeded"
+	// ResultFailed represents that namespace failed to be synchronized
+	ResultFailed = "failed"
+	// ResultSkipped represents that changes of namespace were left unapplied
+	ResultSkipped = "skipped"
+)
+
+// Report represents machine-readable result of running plan
+type Report struct {
+	Table  string `json:"table"`
+	DryRun bool   `json:"dry_run"`
+
+	Namespaces        []*NamespaceReport `json:"namespaces"`
+	DeletedNamespaces []*NamespaceReport `json:"deleted_namespaces"`
+
+	UnprunedNamespaces  []string `json:"unpruned_namespaces"`
+	ProtectedNamespaces []string `json:"protected_namespaces"`
+
+	Errors []string `json:"errors"`
+}
+
+// NamespaceReport represents result of running changes in one namespace
+// Only keys are reported, values are never included.
+type NamespaceReport struct {
+	Namespace   string   `json:"namespace"`
+	Status      string   `json:"status"`
+	Added       []string `json:"added"`
+	Updated     []string `json:"updated"`
+	Deleted     []string `json:"deleted"`
+	Reencrypted []string `json:"reencrypted"`
+	Error       string   `json:"error,omitempty"`
+}
+
+// NewReport creates new report of the given plan whose changes are not applied yet
+func NewReport(p *Plan, dryRun bool) *Report {
+	r := &Report{
+		Table:               p.Table,
+		DryRun:              dryRun,
+		Namespaces:          []*NamespaceReport{},
+		DeletedNamespaces:   []*NamespaceReport{},
+		UnprunedNamespaces:  nonNil(p.UnprunedNamespaces),
+		ProtectedNamespaces: nonNil(p.ProtectedNamespaces),
+		Errors:              []string{},
+	}
+
+	for _, n := range p.Namespaces {
+		status := StatusUnchanged
+		if n.HasChanges() {
+			status = StatusPlanned
+		}
+
+		r.Namespaces = append(r.Namespaces, &NamespaceReport{
+			Namespace:   n.Name,
+			Status:      status,
+			Added:       keys(n.Added),
+			Updated:     keys(n.Updated),
+			Deleted:     keys(n.Deleted),
+			Reencrypted: keys(n.Reencrypted),
+		})
+	}
+
+	for _, n := range p.DeletedNamespaces {
+		r.DeletedNamespaces = append(r.DeletedNamespaces, &NamespaceReport{
+			Namespace:   n.Name,
+			Status:      StatusPlanned,
+			Added:       []string{},
+			Updated:     []string{},
+			Deleted:     []string{},
+			Reencrypted: []string{},
+		})
+	}
+
+	return r
+}
+
+// Fail records the given error which stopped running plan
+func (r *Report) Fail(err error) {
+	r.Errors = append(r.Errors, err.Error())
+}
+
+// FailNamespace records namespace which failed before its changes were planned
+func (r *Report) FailNamespace(namespace string, err error) {
+	r.Namespaces = append(r.Namespaces, &NamespaceReport{
+		Namespace:   namespace,
+		Status:      StatusFailed,
+		Added:       []string{},
+		Updated:     []string{},
+		Deleted:     []string{},
+		Reencrypted: []string{},
+		Error:       err.Error(),
+	})
+}
+
+// Failed returns whether any error occurred
+func (r *Report) Failed() bool {
+	return len(r.Errors) > 0
+}
+
+// Results returns the number of namespaces for each result
+func (r *Report) Results() map[string]int {
+	results := map[string]int{}
+
+	for _, n := range r.Namespaces {
+		results[n.Result()]++
+	}
+
+	for _, n := range r.DeletedNamespaces {
+		results[n.Result()]++
+	}
+
+	return results
+}
+
+// Pending returns whether any changes are left unapplied
+func (r *Report) Pending() bool {
+	for _, n := range r.Namespaces {
+		if n.Status == StatusPlanned || n.Status == StatusFailed {
+			return true
+		}
+	}
+
+	for _, n := range r.DeletedNamespaces {
+		if n.Status == StatusPlanned || n.Status == StatusFailed {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Write writes report as JSON
+func (r *Report) Write(w io.Writer) error {
+	body, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "Failed to convert report as JSON.")
+	}
+
+	if _, err := w.Write(append(body, '\n')); err != nil {
+		return errors.Wrap(err, "Failed to write report.")
+	}
+
+	return nil
+}
+
+// SetStatus records status of the given namespace
+func (n *NamespaceReport) SetStatus(status string, err error) {
+	n.Status = status
+
+	if err != nil {
+		n.Error = err.Error()
+	}
+}
+
+// Result returns whether namespace was synchronized, failed or skipped
+func (n *NamespaceReport) Result() string {
+	switch n.Status {
+	case StatusFailed:
+		return ResultFailed
+	case StatusPlanned:
+		return ResultSkipped
+	default:
+		return ResultSucceeded
+	}
+}
+
+func keys(secrets secret.Secrets) []string {
+	ks := []string{}
+
+	for _, s := range secrets {
+		ks = append(ks, s.Key)
+	}
+
+	return ks
+}
+
+func nonNil(ss []string) []string {
+	if ss == nil {
+		return []string{}
+	}
+
+	return ss
+}
@@ -0,0 +1,232 @@
+package plan
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dtan4/valec/secret"
+	"github.com/pkg/errors"
+)
+
+func TestNewReport(t *testing.T) {
+	p := &Plan{
+		Table: "valec",
+		Namespaces: []*Namespace{
+			&Namespace{
+				Name: "hoge",
+				Added: secret.Secrets{
+					&secret.Secret{Key: "FOO", Value: "foo"},
+				},
+				Updated: secret.Secrets{
+					&secret.Secret{Key: "BAR", Value: "bar"},
+				},
+			},
+			&Namespace{
+				Name: "fuga",
+				Reencrypted: secret.Secrets{
+					&secret.Secret{Key: "BAZ", Value: "baz"},
+				},
+			},
+		},
+		DeletedNamespaces: []*Namespace{
+			&Namespace{
+				Name: "piyo",
+			},
+		},
+		UnprunedNamespaces: []string{"foobar"},
+	}
+
+	expected := &Report{
+		Table:  "valec",
+		DryRun: true,
+		Namespaces: []*NamespaceReport{
+			&NamespaceReport{
+				Namespace:   "hoge",
+				Status:      StatusPlanned,
+				Added:       []string{"FOO"},
+				Updated:     []string{"BAR"},
+				Deleted:     []string{},
+				Reencrypted: []string{},
+			},
+			&NamespaceReport{
+				Namespace:   "fuga",
+				Status:      StatusUnchanged,
+				Added:       []string{},
+				Updated:     []string{},
+				Deleted:     []string{},
+				Reencrypted: []string{"BAZ"},
+			},
+		},
+		DeletedNamespaces: []*NamespaceReport{
+			&NamespaceReport{
+				Namespace:   "piyo",
+				Status:      StatusPlanned,
+				Added:       []string{},
+				Updated:     []string{},
+				Deleted:     []string{},
+				Reencrypted: []string{},
+			},
+		},
+		UnprunedNamespaces:  []string{"foobar"},
+		ProtectedNamespaces: []string{},
+		Errors:              []string{},
+	}
+
+	actual := NewReport(p, true)
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("report does not match. expected: %#v, actual: %#v", expected, actual)
+	}
+}
+
+func TestReportPending(t *testing.T) {
+	testcases := []struct {
+		statuses        []string
+		deletedStatuses []string
+		expected        bool
+	}{
+		{
+			statuses:        []string{},
+			deletedStatuses: []string{},
+			expected:        false,
+		},
+		{
+			statuses:        []string{StatusUnchanged, StatusApplied},
+			deletedStatuses: []string{StatusApplied},
+			expected:        false,
+		},
+		{
+			statuses:        []string{StatusApplied, StatusPlanned},
+			deletedStatuses: []string{},
+			expected:        true,
+		},
+		{
+			statuses:        []string{StatusFailed},
+			deletedStatuses: []string{},
+			expected:        true,
+		},
+		{
+			statuses:        []string{StatusApplied},
+			deletedStatuses: []string{StatusPlanned},
+			expected:        true,
+		},
+	}
+
+	for _, tc := range testcases {
+		r := &Report{}
+
+		for _, status := range tc.statuses {
+			r.Namespaces = append(r.Namespaces, &NamespaceReport{Status: status})
+		}
+
+		for _, status := range tc.deletedStatuses {
+			r.DeletedNamespaces = append(r.DeletedNamespaces, &NamespaceReport{Status: status})
+		}
+
+		if actual := r.Pending(); actual != tc.expected {
+			t.Errorf("result does not match. statuses: %q, deleted: %q, expected: %t, actual: %t", tc.statuses, tc.deletedStatuses, tc.expected, actual)
+		}
+	}
+}
+
+func TestReportFail(t *testing.T) {
+	r := NewReport(&Plan{Table: "valec"}, false)
+
+	if r.Failed() {
+		t.Errorf("report should not be failed.")
+	}
+
+	r.Fail(errors.New("Failed to hoge."))
+
+	if !r.Failed() {
+		t.Errorf("report should be failed.")
+	}
+
+	expected := []string{"Failed to hoge."}
+	if !reflect.DeepEqual(r.Errors, expected) {
+		t.Errorf("errors do not match. expected: %q, actual: %q", expected, r.Errors)
+	}
+}
+
+func TestReportWrite(t *testing.T) {
+	r := NewReport(&Plan{
+		Table: "valec",
+		Namespaces: []*Namespace{
+			&Namespace{
+				Name: "hoge",
+				Added: secret.Secrets{
+					&secret.Secret{Key: "FOO", Value: "secretvalue"},
+				},
+			},
+		},
+	}, false)
+	r.Namespaces[0].SetStatus(StatusFailed, errors.New("Failed to hoge."))
+
+	var buf bytes.Buffer
+
+	if err := r.Write(&buf); err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
+	}
+
+	if strings.Contains(buf.String(), "secretvalue") {
+		t.Errorf("report should not contain secret values. report: %s", buf.String())
+	}
+
+	var actual Report
+	if err := json.Unmarshal(buf.Bytes(), &actual); err != nil {
+		t.Fatalf("report should be valid JSON. error: %s", err)
+	}
+
+	if !reflect.DeepEqual(&actual, r) {
+		t.Errorf("report does not match. expected: %#v, actual: %#v", r, &actual)
+	}
+}
+
+func TestReportFailNamespace(t *testing.T) {
+	r := NewReport(&Plan{Table: "valec"}, false)
+	r.FailNamespace("hoge", errors.New("Failed to hoge."))
+
+	expected := []*NamespaceReport{
+		&NamespaceReport{
+			Namespace:   "hoge",
+			Status:      StatusFailed,
+			Added:       []string{},
+			Updated:     []string{},
+			Deleted:     []string{},
+			Reencrypted: []string{},
+			Error:       "Failed to hoge.",
+		},
+	}
+
+	if !reflect.DeepEqual(r.Namespaces, expected) {
+		t.Errorf("namespaces do not match. expected: %#v, actual: %#v", expected, r.Namespaces)
+	}
+}
+
+func TestReportResults(t *testing.T) {
+	r := &Report{
+		Namespaces: []*NamespaceReport{
+			&NamespaceReport{Status: StatusUnchanged},
+			&NamespaceReport{Status: StatusApplied},
+			&NamespaceReport{Status: StatusPlanned},
+			&NamespaceReport{Status: StatusFailed},
+		},
+		DeletedNamespaces: []*NamespaceReport{
+			&NamespaceReport{Status: StatusApplied},
+			&NamespaceReport{Status: StatusFailed},
+		},
+	}
+
+	expected := map[string]int{
+		ResultSucceeded: 3,
+		ResultFailed:    2,
+		ResultSkipped:   1,
+	}
+
+	if actual := r.Results(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("results do not match. expected: %v, actual: %v", expected, actual)
+	}
+}
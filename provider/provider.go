@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"strings"
+
+	"github.com/dtan4/valec/age"
+	"github.com/dtan4/valec/aws/kms"
+	"github.com/dtan4/valec/envelope"
+)
+
+const (
+	// Age represents local encryption provider using age-style X25519 keys
+	Age = "age"
+	// Envelope represents AWS KMS provider with envelope encryption
+	Envelope = "envelope"
+	// KMS represents AWS KMS provider
+	KMS = "kms"
+
+	// DefaultProvider represents default encryption provider
+	DefaultProvider = KMS
+
+	separator = ":"
+)
+
+// Cipher represents the interface of encryption provider
+type Cipher interface {
+	// DecryptBase64 decrypts the given base64-encoded cipher text bound to namespace and key
+	DecryptBase64(namespace, key, cipherText string) (string, error)
+	// EncryptBase64 encrypts the given text and returns as base64-encoded cipher text bound to namespace and key
+	EncryptBase64(keyAlias, namespace, key, text string) (string, error)
+}
+
+var _ envelope.KeyManager = (*kms.Client)(nil)
+
+var (
+	_ Cipher = (*age.Client)(nil)
+	_ Cipher = (*envelope.Client)(nil)
+	_ Cipher = (*kms.Client)(nil)
+)
+
+// Format attaches provider name to the given cipher text
+// Cipher texts of default provider are left as they are, to keep compatibility with existing secrets.
+func Format(name, cipherText string) string {
+	if name == "" || name == DefaultProvider {
+		return cipherText
+	}
+
+	return name + separator + cipherText
+}
+
+// Parse splits the given cipher text into provider name and provider-specific cipher text
+// Base64 alphabet does not contain separator, so cipher texts without prefix are treated as the ones of default provider.
+func Parse(cipherText string) (string, string) {
+	ss := strings.SplitN(cipherText, separator, 2)
+	if len(ss) < 2 {
+		return DefaultProvider, cipherText
+	}
+
+	return ss[0], ss[1]
+}
+
+// NameOrDefault returns the given provider name, or default provider name if it is empty
+func NameOrDefault(name string) string {
+	if name == "" {
+		return DefaultProvider
+	}
+
+	return name
+}
@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	testcases := []struct {
+		name       string
+		cipherText string
+		expected   string
+	}{
+		{
+			name:       "",
+			cipherText: "AQECAHi1osu8IsEnPMo1",
+			expected:   "AQECAHi1osu8IsEnPMo1",
+		},
+		{
+			name:       "kms",
+			cipherText: "AQECAHi1osu8IsEnPMo1",
+			expected:   "AQECAHi1osu8IsEnPMo1",
+		},
+		{
+			name:       "foo",
+			cipherText: "AQECAHi1osu8IsEnPMo1",
+			expected:   "foo:AQECAHi1osu8IsEnPMo1",
+		},
+	}
+
+	for _, tc := range testcases {
+		actual := Format(tc.name, tc.cipherText)
+		if actual != tc.expected {
+			t.Errorf("Cipher text does not match. expected: %q, actual: %q", tc.expected, actual)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	testcases := []struct {
+		cipherText string
+		name       string
+		body       string
+	}{
+		{
+			cipherText: "AQECAHi1osu8IsEnPMo1",
+			name:       "kms",
+			body:       "AQECAHi1osu8IsEnPMo1",
+		},
+		{
+			cipherText: "foo:AQECAHi1osu8IsEnPMo1",
+			name:       "foo",
+			body:       "AQECAHi1osu8IsEnPMo1",
+		},
+	}
+
+	for _, tc := range testcases {
+		name, body := Parse(tc.cipherText)
+		if name != tc.name {
+			t.Errorf("Provider name does not match. expected: %q, actual: %q", tc.name, name)
+		}
+
+		if body != tc.body {
+			t.Errorf("Cipher text does not match. expected: %q, actual: %q", tc.body, body)
+		}
+	}
+}
+
+func TestNameOrDefault(t *testing.T) {
+	if actual := NameOrDefault(""); actual != "kms" {
+		t.Errorf("Default provider should be returned. actual: %q", actual)
+	}
+
+	if actual := NameOrDefault("foo"); actual != "foo" {
+		t.Errorf("Given provider should be returned. actual: %q", actual)
+	}
+}
@@ -1,10 +1,13 @@
 package secret
 
 import (
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/dtan4/valec/util"
 	"github.com/pkg/errors"
@@ -20,15 +23,93 @@ const (
 type Secret struct {
 	Key   string `yaml:"key"`
 	Value string `yaml:"value"`
+
+	// Replicas are cipher texts of the same value encrypted with secondary KMS keys
+	Replicas []*Replica `yaml:"replicas,omitempty"`
+
+	// DataKey is the encrypted data key which Value is sealed with (envelope provider only)
+	DataKey string `yaml:"-"`
+	// Region is the region of KMS key which Value is encrypted with
+	Region string `yaml:"-"`
+	// KeyARN is the ARN of KMS key which Value (or DataKey of envelope provider) is encrypted with
+	// It is recorded when the secret is synchronized, so that KMS key can be checked without decrypting Value.
+	KeyARN string `yaml:"-"`
+	// Version is the version of the stored secret which this secret was read from or will overwrite
+	Version int64 `yaml:"-"`
+	// UpdatedAt is the time when the stored secret was written
+	UpdatedAt time.Time `yaml:"-"`
+	// UpdatedBy is the ARN of IAM user or role which wrote the stored secret
+	UpdatedBy string `yaml:"-"`
+	// Commit is the git commit of secret files which the stored secret was written from
+	Commit string `yaml:"-"`
+	// HistoryID identifies the previous version in history, which is empty for secrets not read from history
+	HistoryID string `yaml:"-"`
+}
+
+// Replica represents cipher text encrypted with secondary KMS key
+type Replica struct {
+	Region string `yaml:"region,omitempty"`
+	Value  string `yaml:"value"`
+	// KeyARN is the ARN of KMS key which Value is encrypted with
+	KeyARN string `yaml:"-"`
+}
+
+// KMSKeyConfig represents KMS key used to encrypt secrets
+type KMSKeyConfig struct {
+	Alias  string `yaml:"alias"`
+	Region string `yaml:"region,omitempty"`
 }
 
 // Secrets represents the array of Secret
 type Secrets []*Secret
 
+// Trashed represents deleted secret which can be restored until it expires
+type Trashed struct {
+	// ID identifies the deleted secret in trash
+	ID        string
+	Namespace string
+	Secret    *Secret
+	DeletedAt time.Time
+	ExpiresAt time.Time
+}
+
+// ConflictError represents that stored secrets were changed by someone else after they were read
+type ConflictError struct {
+	Namespace string
+	Keys      []string
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("Secrets were changed by someone else. namespace=%s, keys=%s", e.Namespace, strings.Join(e.Keys, ","))
+}
+
 // YAML represents secret yaml structure
 type YAML struct {
-	KMSKey  string  `yaml:"kms_key"`
-	Secrets Secrets `yaml:"secrets"`
+	Provider   string          `yaml:"provider,omitempty"`
+	KMSKey     string          `yaml:"kms_key,omitempty"`
+	KMSKeys    []*KMSKeyConfig `yaml:"kms_keys,omitempty"`
+	DataKey    string          `yaml:"data_key,omitempty"`
+	Recipients []string        `yaml:"recipients,omitempty"`
+	Secrets    Secrets         `yaml:"secrets"`
+}
+
+// Keys returns KMS keys declared in secret yaml
+// The first key is the primary one, and the rest are used to encrypt replicas.
+func (y *YAML) Keys() []*KMSKeyConfig {
+	if len(y.KMSKeys) > 0 {
+		return y.KMSKeys
+	}
+
+	kmsKey := y.KMSKey
+	if kmsKey == "" {
+		kmsKey = DefaultKMSKey
+	}
+
+	return []*KMSKeyConfig{
+		&KMSKeyConfig{
+			Alias: kmsKey,
+		},
+	}
 }
 
 // Len returns the length of the array
@@ -66,13 +147,17 @@ func (ss Secrets) Swap(i, j int) {
 
 // CompareList compares two secret lists and returns the differences between them
 func (ss Secrets) CompareList(old Secrets) (added, updated, deleted Secrets) {
-	newMap, oldMap := ss.ListToMap(), old.ListToMap()
+	newMap, oldMap := ss.ListToMap(), map[string]*Secret{}
+
+	for _, c := range old {
+		oldMap[c.Key] = c
+	}
 
 	for _, c := range ss {
 		v, ok := oldMap[c.Key]
 		if !ok {
 			added = append(added, c)
-		} else if v != c.Value {
+		} else if !v.sameCipherTexts(c) {
 			updated = append(updated, c)
 		}
 	}
@@ -87,6 +172,21 @@ func (ss Secrets) CompareList(old Secrets) (added, updated, deleted Secrets) {
 	return added, updated, deleted
 }
 
+// sameCipherTexts returns whether two secrets hold the same cipher texts including replicas
+func (s *Secret) sameCipherTexts(other *Secret) bool {
+	if s.Value != other.Value || len(s.Replicas) != len(other.Replicas) {
+		return false
+	}
+
+	for i := range s.Replicas {
+		if s.Replicas[i].Region != other.Replicas[i].Region || s.Replicas[i].Value != other.Replicas[i].Value {
+			return false
+		}
+	}
+
+	return true
+}
+
 // ListToMap converts secret list to map
 func (ss Secrets) ListToMap() map[string]string {
 	secretMap := map[string]string{}
@@ -105,6 +205,11 @@ func (ss Secrets) SaveAsYAML(filename, kmsKey string) error {
 		Secrets: ss,
 	}
 
+	return y.Save(filename)
+}
+
+// Save saves secret yaml to local secret file
+func (y *YAML) Save(filename string) error {
 	body, err := yaml.Marshal(y)
 	if err != nil {
 		return errors.Wrap(err, "Failed to convert secrets as YAML.")
@@ -126,18 +231,35 @@ func (ss Secrets) SaveAsYAML(filename, kmsKey string) error {
 
 // LoadFromYAML loads secrets from the given YAML file
 func LoadFromYAML(filename string) (string, Secrets, error) {
+	y, err := LoadYAML(filename)
+	if err != nil {
+		return "", Secrets{}, err
+	}
+
+	return y.KMSKey, y.Secrets, nil
+}
+
+// LoadYAML loads secret yaml from the given YAML file
+func LoadYAML(filename string) (*YAML, error) {
 	body, err := ioutil.ReadFile(filename)
 	if err != nil {
-		return "", Secrets{}, errors.Wrapf(err, "Failed to read secret file. filename=%s", filename)
+		return nil, errors.Wrapf(err, "Failed to read secret file. filename=%s", filename)
 	}
 
 	var y YAML
 
 	if err := yaml.Unmarshal(body, &y); err != nil {
-		return "", Secrets{}, errors.Wrapf(err, "Failed to parse secret file as YAML. filename=%s", filename)
+		return nil, errors.Wrapf(err, "Failed to parse secret file as YAML. filename=%s", filename)
 	}
 
-	return y.KMSKey, y.Secrets, nil
+	region := y.Keys()[0].Region
+
+	for _, secret := range y.Secrets {
+		secret.DataKey = y.DataKey
+		secret.Region = region
+	}
+
+	return &y, nil
 }
 
 // MapToList converts map to secret list
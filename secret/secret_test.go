@@ -79,7 +79,7 @@ func TestLess(t *testing.T) {
 	for _, tc := range testcases {
 		actual := secrets.Less(tc.i, tc.j)
 		if actual != tc.expected {
-			t.Errorf("Comparison result is wrong. src: %q, dst: %q, expected: %t, actual: %t", secrets[tc.i], secrets[tc.j], tc.expected, actual)
+			t.Errorf("Comparison result is wrong. src: %v, dst: %v, expected: %t, actual: %t", secrets[tc.i], secrets[tc.j], tc.expected, actual)
 		}
 	}
 }
@@ -195,6 +195,68 @@ func TestCompareList(t *testing.T) {
 	}
 }
 
+func TestCompareList_replicas(t *testing.T) {
+	newSecrets := Secrets{
+		&Secret{
+			Key:   "FOO",
+			Value: "bar",
+			Replicas: []*Replica{
+				&Replica{
+					Region: "us-west-2",
+					Value:  "baz",
+				},
+			},
+		},
+		&Secret{
+			Key:   "BAZ",
+			Value: "1",
+			Replicas: []*Replica{
+				&Replica{
+					Region: "us-west-2",
+					Value:  "2",
+				},
+			},
+		},
+	}
+	oldSecrets := Secrets{
+		&Secret{
+			Key:   "FOO",
+			Value: "bar",
+		},
+		&Secret{
+			Key:   "BAZ",
+			Value: "1",
+			Replicas: []*Replica{
+				&Replica{
+					Region: "us-west-2",
+					Value:  "2",
+				},
+			},
+		},
+	}
+
+	expectUpdated := Secrets{
+		&Secret{
+			Key:   "FOO",
+			Value: "bar",
+		},
+	}
+
+	added, updated, deleted := newSecrets.CompareList(oldSecrets)
+
+	if len(added) != 0 {
+		t.Errorf("No secret should be added. actual: %s", stringifySecretList(added))
+	}
+
+	if !secretListsEqual(updated, expectUpdated) {
+		t.Errorf("Returned updated secrets are wrong. expected: %s, actual: %s", stringifySecretList(expectUpdated), stringifySecretList(updated))
+	}
+
+	if len(deleted) != 0 {
+		t.Errorf("No secret should be deleted. actual: %s", stringifySecretList(deleted))
+	}
+}
+
 func secretListsEqual(a, b Secrets) bool {
 	if len(a) != len(b) {
 		return false
@@ -368,6 +430,170 @@ func TestLoadFromYAML_invalid(t *testing.T) {
 	}
 }
 
+func TestLoadYAML(t *testing.T) {
+	y, err := LoadYAML(testdataPath("test_provider.yaml"))
+	if err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
+	}
+
+	expected := &YAML{
+		Provider: "foo",
+		Secrets: Secrets{
+			&Secret{
+				Key:   "FOO",
+				Value: "foo:bar",
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(y, expected) {
+		t.Errorf("YAML does not match. expected: %#v, actual: %#v", expected, y)
+	}
+}
+
+func TestLoadYAML_dataKey(t *testing.T) {
+	y, err := LoadYAML(testdataPath("test_envelope.yaml"))
+	if err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
+	}
+
+	if y.DataKey != "d3JhcHBlZA==" {
+		t.Errorf("Data key does not match. expected: %q, actual: %q", "d3JhcHBlZA==", y.DataKey)
+	}
+
+	for _, secret := range y.Secrets {
+		if secret.DataKey != y.DataKey {
+			t.Errorf("Data key of secret does not match. expected: %q, actual: %q, key: %s", y.DataKey, secret.DataKey, secret.Key)
+		}
+	}
+}
+
+func TestLoadYAML_kmsKeys(t *testing.T) {
+	y, err := LoadYAML(testdataPath("test_kms_keys.yaml"))
+	if err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
+	}
+
+	expected := &YAML{
+		KMSKeys: []*KMSKeyConfig{
+			&KMSKeyConfig{
+				Alias:  "valec",
+				Region: "ap-northeast-1",
+			},
+			&KMSKeyConfig{
+				Alias:  "valec-dr",
+				Region: "us-west-2",
+			},
+		},
+		Secrets: Secrets{
+			&Secret{
+				Key:   "FOO",
+				Value: "primary",
+				Replicas: []*Replica{
+					&Replica{
+						Region: "us-west-2",
+						Value:  "replica",
+					},
+				},
+				Region: "ap-northeast-1",
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(y, expected) {
+		t.Errorf("YAML does not match. expected: %#v, actual: %#v", expected, y)
+	}
+}
+
+func TestYAMLKeys(t *testing.T) {
+	testcases := []struct {
+		y        *YAML
+		expected []*KMSKeyConfig
+	}{
+		{
+			y: &YAML{},
+			expected: []*KMSKeyConfig{
+				&KMSKeyConfig{
+					Alias: "valec",
+				},
+			},
+		},
+		{
+			y: &YAML{
+				KMSKey: "valec-qa",
+			},
+			expected: []*KMSKeyConfig{
+				&KMSKeyConfig{
+					Alias: "valec-qa",
+				},
+			},
+		},
+		{
+			y: &YAML{
+				KMSKeys: []*KMSKeyConfig{
+					&KMSKeyConfig{
+						Alias: "valec",
+					},
+					&KMSKeyConfig{
+						Alias:  "valec-dr",
+						Region: "us-west-2",
+					},
+				},
+			},
+			expected: []*KMSKeyConfig{
+				&KMSKeyConfig{
+					Alias: "valec",
+				},
+				&KMSKeyConfig{
+					Alias:  "valec-dr",
+					Region: "us-west-2",
+				},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		actual := tc.y.Keys()
+
+		if !reflect.DeepEqual(actual, tc.expected) {
+			t.Errorf("Keys do not match. expected: %#v, actual: %#v", tc.expected, actual)
+		}
+	}
+}
+
+func TestYAMLSave(t *testing.T) {
+	y := &YAML{
+		Provider: "foo",
+		Secrets: Secrets{
+			&Secret{
+				Key:   "FOO",
+				Value: "foo:bar",
+			},
+		},
+	}
+
+	dir, err := ioutil.TempDir("", "test-yaml-save")
+	if err != nil {
+		t.Fatalf("Failed to create tempdir. dir: %s", dir)
+	}
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "secret.yaml")
+
+	if err := y.Save(filename); err != nil {
+		t.Fatalf("Error should not be raised. err: %s", err)
+	}
+
+	actual, err := LoadYAML(filename)
+	if err != nil {
+		t.Fatalf("Error should not be raised. err: %s", err)
+	}
+
+	if !reflect.DeepEqual(actual, y) {
+		t.Errorf("YAML does not match. expected: %#v, actual: %#v", y, actual)
+	}
+}
+
 func TestMapToList(t *testing.T) {
 	secretMap := map[string]string{
 		"FOO":  "bar",
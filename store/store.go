@@ -0,0 +1,62 @@
+package store
+
+import (
+	"github.com/dtan4/valec/aws"
+	"github.com/dtan4/valec/aws/dynamodb"
+	"github.com/dtan4/valec/secret"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DynamoDB represents DynamoDB backend
+	DynamoDB = "dynamodb"
+
+	// DefaultBackend represents default storage backend
+	DefaultBackend = DynamoDB
+)
+
+// SecretStore represents the interface of secret storage backend
+type SecretStore interface {
+	// Apply writes and deletes secrets in the namespace atomically
+	// Originals are stored secrets which the written secrets overwrite, kept in history and used to revert changes on failure.
+	Apply(table, namespace string, puts, deletes, originals []*secret.Secret) error
+	// Delete deletes the given secrets from the namespace
+	Delete(table, namespace string, secrets []*secret.Secret) error
+	// DeleteNamespace deletes all secrets in the given namespace
+	DeleteNamespace(table, namespace string) error
+	// Get returns a secret with the given key
+	Get(table, namespace, key string) (*secret.Secret, error)
+	// History returns previous versions of the secret with the given key, oldest first
+	History(table, namespace, key string) ([]*secret.Secret, error)
+	// Insert creates / updates the given secrets in the namespace
+	Insert(table, namespace string, secrets []*secret.Secret) error
+	// ListNamespaces returns all namespaces
+	ListNamespaces(table string) ([]string, error)
+	// ListSecrets returns all secrets in the given namespace
+	ListSecrets(table, namespace string) ([]*secret.Secret, error)
+	// ListTrash returns deleted secrets in the given namespace which are not expired yet, or in all namespaces if namespace is empty
+	ListTrash(table, namespace string) ([]*secret.Trashed, error)
+	// MigrateNamespaceRegistry registers all existing namespaces so that they are listed without scanning whole storage
+	MigrateNamespaceRegistry(table string) ([]string, error)
+	// NamespaceExists checks whether the given namespace exists or not
+	NamespaceExists(table, namespace string) (bool, error)
+	// Restore writes back the given deleted secrets to the namespace and removes them from trash
+	Restore(table, namespace string, trashed []*secret.Trashed) error
+}
+
+var _ SecretStore = (*dynamodb.Client)(nil)
+
+// New returns SecretStore of the given backend
+// AWS API clients must be initialized beforehand.
+func New(backend string) (SecretStore, error) {
+	switch backend {
+	case DynamoDB:
+		if aws.DynamoDB == nil {
+			return nil, errors.New("DynamoDB client is not initialized.")
+		}
+
+		return aws.DynamoDB, nil
+	default:
+		return nil, errors.Errorf("Unknown backend. backend=%s", backend)
+	}
+}
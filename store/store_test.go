@@ -0,0 +1,40 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/dtan4/valec/aws"
+	"github.com/dtan4/valec/aws/dynamodb"
+	"github.com/dtan4/valec/aws/mock"
+	"github.com/golang/mock/gomock"
+)
+
+func TestNew(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := dynamodb.NewClient(mock.NewMockDynamoDBAPI(ctrl))
+	aws.DynamoDB = client
+	defer func() { aws.DynamoDB = nil }()
+
+	s, err := New("dynamodb")
+	if err != nil {
+		t.Errorf("Error should not be raised. error: %s", err)
+	}
+
+	if s != client {
+		t.Errorf("DynamoDB client should be returned.")
+	}
+}
+
+func TestNew_uninitialized(t *testing.T) {
+	if _, err := New("dynamodb"); err == nil {
+		t.Errorf("Error should be raised.")
+	}
+}
+
+func TestNew_unknown(t *testing.T) {
+	if _, err := New("foobar"); err == nil {
+		t.Errorf("Error should be raised.")
+	}
+}
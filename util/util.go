@@ -2,13 +2,18 @@ package util
 
 import (
 	"bufio"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"io"
 	"io/ioutil"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"regexp"
 	"sort"
 	"strings"
+	"sync"
 
 	"github.com/Songmu/prompter"
 	"github.com/pkg/errors"
@@ -19,6 +24,15 @@ var (
 	yamlExtRegexp   = regexp.MustCompile(`\.[yY][aA]?[mM][lL]$`)
 )
 
+// reservedNamespaces are namespaces used by DynamoDB backend, including their children
+// ".migrated" is the marker key in namespace registry, so it cannot be registered as a namespace.
+var reservedNamespaces = []string{
+	".history",
+	".migrated",
+	".namespaces",
+	".trash",
+}
+
 // CompareStrings compares two string slices
 func CompareStrings(src, dst []string) ([]string, []string) {
 	added, deleted := []string{}, []string{}
@@ -48,6 +62,34 @@ func CompareStrings(src, dst []string) ([]string, []string) {
 	return added, deleted
 }
 
+// Fingerprint returns short fingerprint of the given text keyed with the given secret
+// Plain SHA-256 digest of low-entropy text can be reversed by brute force, so HMAC-SHA256 is used instead.
+// Fingerprints are comparable only if they are computed with the same secret.
+func Fingerprint(secret []byte, text string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(text))
+
+	return "hmac:" + hex.EncodeToString(mac.Sum(nil)[:4])
+}
+
+// GitCommit returns the git commit which the given directory is checked out at
+// "-dirty" is appended if files in the directory have uncommitted changes.
+// Empty string is returned if the directory is not in git repository.
+func GitCommit(dirname string) string {
+	out, err := exec.Command("git", "-C", dirname, "rev-parse", "HEAD").Output()
+	if err != nil {
+		return ""
+	}
+	commit := strings.TrimSpace(string(out))
+
+	status, err := exec.Command("git", "-C", dirname, "status", "--porcelain", "--", ".").Output()
+	if err == nil && len(strings.TrimSpace(string(status))) > 0 {
+		commit += "-dirty"
+	}
+
+	return commit
+}
+
 // IsExist returns whether the given file / directory exists or not
 func IsExist(name string) bool {
 	_, err := os.Stat(name)
@@ -61,6 +103,22 @@ func IsSecretFile(filename string) bool {
 	return !strings.HasPrefix(base, ".") && yamlExtRegexp.MatchString(filepath.Ext(base))
 }
 
+// ValidateNamespace returns error if the given namespace cannot be used
+// Namespaces used by storage backend for namespace registry, history and trash are reserved.
+func ValidateNamespace(namespace string) error {
+	if namespace == "" {
+		return errors.New("Namespace is empty.")
+	}
+
+	for _, reserved := range reservedNamespaces {
+		if namespace == reserved || strings.HasPrefix(namespace, reserved+"/") {
+			return errors.Errorf("Namespace is reserved. namespace=%s", namespace)
+		}
+	}
+
+	return nil
+}
+
 // NamespaceFromPath returns namespace from the given path
 func NamespaceFromPath(path, basedir string) (string, error) {
 	var namespace string
@@ -86,6 +144,30 @@ func NamespaceFromPath(path, basedir string) (string, error) {
 	return namespace, nil
 }
 
+// PathFromNamespace returns path of secret file of the given namespace
+// This is the inverse of NamespaceFromPath. Existing ".yml" file is preferred, otherwise ".yaml" is used.
+// Namespaces which point outside of basedir (e.g. "../foo" or "/etc/foo") are rejected.
+func PathFromNamespace(namespace, basedir string) (string, error) {
+	for _, elem := range strings.Split(namespace, "/") {
+		if elem == "" || elem == "." || elem == ".." {
+			return "", errors.Errorf("Namespace is not a relative path. namespace=%s", namespace)
+		}
+	}
+
+	base := filepath.Join(basedir, filepath.FromSlash(namespace))
+
+	rel, err := filepath.Rel(basedir, base)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", errors.Errorf("Namespace points outside of directory. namespace=%s, dirname=%s", namespace, basedir)
+	}
+
+	if IsExist(base + ".yml") {
+		return base + ".yml", nil
+	}
+
+	return base + ".yaml", nil
+}
+
 // ListYAMLFiles parses and executes function recursively
 func ListYAMLFiles(dirname string) ([]string, error) {
 	files := []string{}
@@ -121,6 +203,46 @@ func ListYAMLFiles(dirname string) ([]string, error) {
 	return files, nil
 }
 
+// Parallel calls fn with each index in [0, n) using the given number of workers
+// All calls are completed even if some of them fail, and the error of the smallest index is returned.
+func Parallel(n, concurrency int, fn func(i int) error) error {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	errs := make([]error, n)
+	indices := make(chan int)
+
+	var wg sync.WaitGroup
+
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range indices {
+				errs[i] = fn(i)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		indices <- i
+	}
+	close(indices)
+
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // ScanLines reads text stream and return
 func ScanLines(r io.Reader) []string {
 	lines := []string{}
@@ -133,6 +255,12 @@ func ScanLines(r io.Reader) []string {
 	return lines
 }
 
+// Confirm asks the given yes/no question in console
+// Answer is no unless yes is given explicitly.
+func Confirm(question string) bool {
+	return prompter.YN(question, false)
+}
+
 // ScanNoecho reads password without printing password text in console
 func ScanNoecho(key string) string {
 	return prompter.Password(key)
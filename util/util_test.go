@@ -2,11 +2,15 @@ package util
 
 import (
 	"bytes"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"reflect"
+	"regexp"
+	"sync/atomic"
 	"testing"
 )
 
@@ -68,6 +72,83 @@ func TestCompareStrings(t *testing.T) {
 	}
 }
 
+func TestFingerprint(t *testing.T) {
+	testcases := []struct {
+		secret   string
+		text     string
+		expected string
+	}{
+		{
+			secret:   "secret",
+			text:     "",
+			expected: "hmac:f9e66e17",
+		},
+		{
+			secret:   "secret",
+			text:     "fuga",
+			expected: "hmac:68221311",
+		},
+		{
+			secret:   "another",
+			text:     "fuga",
+			expected: "hmac:4f6eeed7",
+		},
+	}
+
+	for _, tc := range testcases {
+		if actual := Fingerprint([]byte(tc.secret), tc.text); actual != tc.expected {
+			t.Errorf("Fingerprint does not match. secret: %q, text: %q, expected: %q, actual: %q", tc.secret, tc.text, tc.expected, actual)
+		}
+	}
+
+	if Fingerprint([]byte("secret"), "fuga") == Fingerprint([]byte("secret"), "hoge") {
+		t.Errorf("Fingerprints of different texts should not match.")
+	}
+}
+
+func TestGitCommit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed.")
+	}
+
+	dir, err := ioutil.TempDir("", "valec-git")
+	if err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	if actual := GitCommit(dir); actual != "" {
+		t.Errorf("Commit should be empty outside of git repository. actual: %q", actual)
+	}
+
+	git := func(args ...string) {
+		args = append([]string{"-C", dir, "-c", "user.name=valec", "-c", "user.email=valec@example.com"}, args...)
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("Failed to run git. error: %s, output: %s", err, out)
+		}
+	}
+
+	git("init", "-q")
+	if err := ioutil.WriteFile(filepath.Join(dir, "hoge.yaml"), []byte("secrets: []\n"), 0644); err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
+	}
+	git("add", ".")
+	git("commit", "-q", "-m", "init")
+
+	commit := GitCommit(dir)
+	if !regexp.MustCompile(`^[0-9a-f]{40}$`).MatchString(commit) {
+		t.Errorf("Commit does not match. actual: %q", commit)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "hoge.yaml"), []byte("secrets: [{key: FOO, value: bar}]\n"), 0644); err != nil {
+		t.Fatalf("Error should not be raised. error: %s", err)
+	}
+
+	if expected, actual := commit+"-dirty", GitCommit(dir); actual != expected {
+		t.Errorf("Commit does not match. expected: %q, actual: %q", expected, actual)
+	}
+}
+
 func TestIsExist(t *testing.T) {
 	dir, err := ioutil.TempDir("", "test-save-as-dotenv")
 	if err != nil {
@@ -181,6 +262,135 @@ func TestNamespaceFromPath(t *testing.T) {
 	}
 }
 
+func TestPathFromNamespace(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test-path-from-namespace")
+	if err != nil {
+		t.Fatalf("Failed to create temporary directory. error: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "bar.yml"), []byte{}, 0644); err != nil {
+		t.Fatalf("Failed to create file. error: %s", err)
+	}
+
+	testcases := []struct {
+		namespace string
+		expected  string
+	}{
+		{
+			namespace: "foo",
+			expected:  filepath.Join(dir, "foo.yaml"),
+		},
+		{
+			namespace: "bar",
+			expected:  filepath.Join(dir, "bar.yml"),
+		},
+		{
+			namespace: "foo/bar/baz",
+			expected:  filepath.Join(dir, "foo", "bar", "baz.yaml"),
+		},
+	}
+
+	for _, tc := range testcases {
+		actual, err := PathFromNamespace(tc.namespace, dir)
+		if err != nil {
+			t.Errorf("Error should not be raised. error: %s", err)
+		}
+
+		if actual != tc.expected {
+			t.Errorf("Path does not match. expected: %q, actual: %q", tc.expected, actual)
+		}
+
+		namespace, err := NamespaceFromPath(actual, dir)
+		if err != nil {
+			t.Errorf("Error should not be raised. error: %s", err)
+		}
+
+		if namespace != tc.namespace {
+			t.Errorf("Namespace does not match. expected: %q, actual: %q", tc.namespace, namespace)
+		}
+	}
+}
+
+func TestPathFromNamespace_invalid(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test-path-from-namespace")
+	if err != nil {
+		t.Fatalf("Failed to create temporary directory. error: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	namespaces := []string{
+		"",
+		"../foo",
+		"foo/../../bar",
+		"/etc/foo",
+		"foo//bar",
+		"./foo",
+	}
+
+	for _, namespace := range namespaces {
+		if _, err := PathFromNamespace(namespace, dir); err == nil {
+			t.Errorf("Error should be raised. namespace: %q", namespace)
+		}
+	}
+}
+
+func TestValidateNamespace(t *testing.T) {
+	testcases := []struct {
+		namespace string
+		valid     bool
+	}{
+		{
+			namespace: "foo",
+			valid:     true,
+		},
+		{
+			namespace: "foo/.bar",
+			valid:     true,
+		},
+		{
+			namespace: ".hidden/foo",
+			valid:     true,
+		},
+		{
+			namespace: "",
+			valid:     false,
+		},
+		{
+			namespace: ".history",
+			valid:     false,
+		},
+		{
+			namespace: ".history/foo",
+			valid:     false,
+		},
+		{
+			namespace: ".migrated",
+			valid:     false,
+		},
+		{
+			namespace: ".namespaces",
+			valid:     false,
+		},
+		{
+			namespace: ".trash",
+			valid:     false,
+		},
+	}
+
+	for _, tc := range testcases {
+		err := ValidateNamespace(tc.namespace)
+
+		if tc.valid && err != nil {
+			t.Errorf("Error should not be raised. namespace: %q, error: %s", tc.namespace, err)
+		}
+
+		if !tc.valid && err == nil {
+			t.Errorf("Error should be raised. namespace: %q", tc.namespace)
+		}
+	}
+}
+
 func TestListYAMLFiles(t *testing.T) {
 	dirname := filepath.Join("..", "testdata", "foo")
 	expected := []string{
@@ -198,6 +408,49 @@ func TestListYAMLFiles(t *testing.T) {
 	}
 }
 
+func TestParallel(t *testing.T) {
+	n := 100
+	results := make([]int, n)
+
+	if err := Parallel(n, 8, func(i int) error {
+		results[i] = i * 2
+		return nil
+	}); err != nil {
+		t.Errorf("Error should not be raised. error: %s", err)
+	}
+
+	for i, result := range results {
+		if result != i*2 {
+			t.Errorf("Result does not match. index: %d, expected: %d, actual: %d", i, i*2, result)
+		}
+	}
+}
+
+func TestParallel_error(t *testing.T) {
+	var called int32
+
+	err := Parallel(10, 3, func(i int) error {
+		atomic.AddInt32(&called, 1)
+
+		if i == 3 || i == 7 {
+			return fmt.Errorf("error %d", i)
+		}
+
+		return nil
+	})
+	if err == nil {
+		t.Fatalf("Error should be raised.")
+	}
+
+	if err.Error() != "error 3" {
+		t.Errorf("Error of the smallest index should be returned. expected: %q, actual: %q", "error 3", err.Error())
+	}
+
+	if called != 10 {
+		t.Errorf("All calls should be completed. expected: %d, actual: %d", 10, called)
+	}
+}
+
 func TestScanLines(t *testing.T) {
 	body := `FOO=bar
 BAZ=1